@@ -15,6 +15,7 @@ const (
 type AccessTokenFileHandlerInterface interface {
 	Read() (string, error)
 	Write(token string) error
+	Delete() error
 }
 
 type AccessTokenFileHandler struct {
@@ -28,6 +29,7 @@ type AccessTokenCreatorInterface interface {
 type AccessTokenCreator struct {
 	browserPath  string
 	redirectPort int
+	apiUri       string
 }
 
 func NewAccessAccessTokenCreator(browserPath string, redirectPort int) *AccessTokenCreator {
@@ -37,6 +39,13 @@ func NewAccessAccessTokenCreator(browserPath string, redirectPort int) *AccessTo
 	}
 }
 
+// WithApiUri makes the creator exchange tokens with the API host of another
+// environment than the sandbox.
+func (c *AccessTokenCreator) WithApiUri(apiUri string) *AccessTokenCreator {
+	c.apiUri = apiUri
+	return c
+}
+
 func NewAccessTokenFileHandler(filePath string) *AccessTokenFileHandler {
 	return &AccessTokenFileHandler{
 		filePath: filePath,
@@ -80,7 +89,18 @@ func (c *AccessTokenCreator) NewToken(appId string, appSecret string) (string, e
 		Scope:        scope,
 		RedirectPort: c.redirectPort,
 		BrowserPath:  c.browserPath,
+		ApiUri:       c.apiUri,
 	})
 
 	return oauth.CreateAccessToken()
 }
+
+// Delete removes the token file so the next Read fails and a new token is
+// created. A missing file isn't an error.
+func (h *AccessTokenFileHandler) Delete() error {
+	err := os.Remove(h.filePath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
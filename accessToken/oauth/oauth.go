@@ -39,9 +39,17 @@ type OAuthConfig struct {
 	Scope        string
 	RedirectPort int
 	BrowserPath  string
+	// ApiUri is the API host the auth code is exchanged with. It defaults to
+	// the sandbox.
+	ApiUri string
 }
 
 func NewOAuth(cfg OAuthConfig) *OAuth {
+	uri := apiUri
+	if cfg.ApiUri != "" {
+		uri = cfg.ApiUri
+	}
+
 	return &OAuth{
 		appId:              cfg.AppId,
 		appSecret:          cfg.AppSecret,
@@ -50,7 +58,7 @@ func NewOAuth(cfg OAuthConfig) *OAuth {
 		redirectLandingUri: fmt.Sprintf("%s/%s/", redirectLandingBaseUri, cfg.AppId),
 		redirectPort:       cfg.RedirectPort,
 		oAuthUri:           oAuthUri,
-		apiUri:             apiUri,
+		apiUri:             uri,
 		browserPath:        cfg.BrowserPath,
 	}
 }
@@ -0,0 +1,260 @@
+package boards
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/go-logr/logr"
+
+	"pin-creator/pinterest"
+)
+
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// FieldChange is a board setting that differs from its spec.
+type FieldChange struct {
+	Field string
+	From  string
+	To    string
+}
+
+// Change is one action needed to bring a board in line with its spec.
+type Change struct {
+	Action         string
+	BoardName      string
+	BoardId        string
+	Spec           Spec
+	Fields         []FieldChange
+	AddSections    []string
+	DeleteSections []pinterest.BoardSection
+}
+
+// Plan is the list of changes that make the live account match the specs.
+type Plan struct {
+	Changes []Change
+}
+
+// NewPlan compares the specs with the boards of the account. Boards and
+// sections that aren't described by the specs are only deleted when
+// deleteUnmanaged is set.
+func NewPlan(ctx context.Context, client pinterest.ClientInterface, specs []Spec, deleteUnmanaged bool) (*Plan, error) {
+	boards, err := client.ListBoards(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing boards: %w", err)
+	}
+
+	byName := make(map[string]pinterest.BoardInfo, len(boards))
+	for _, board := range boards {
+		byName[board.Name] = board
+	}
+
+	plan := &Plan{}
+	for _, spec := range specs {
+		board, ok := byName[spec.Name]
+		if !ok {
+			plan.Changes = append(plan.Changes, Change{
+				Action:      ActionCreate,
+				BoardName:   spec.Name,
+				Spec:        spec,
+				AddSections: spec.Sections,
+			})
+			continue
+		}
+
+		change := Change{
+			Action:    ActionUpdate,
+			BoardName: spec.Name,
+			BoardId:   board.Id,
+			Spec:      spec,
+		}
+		// fields the spec leaves empty aren't managed by the boards file
+		if spec.Description != "" && board.Description != spec.Description {
+			change.Fields = append(change.Fields, FieldChange{Field: "description", From: board.Description, To: spec.Description})
+		}
+		if spec.Privacy != "" && board.Privacy != spec.Privacy {
+			change.Fields = append(change.Fields, FieldChange{Field: "privacy", From: board.Privacy, To: spec.Privacy})
+		}
+
+		if len(spec.Sections) > 0 || deleteUnmanaged {
+			err := diffSections(ctx, client, &change, deleteUnmanaged)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if len(change.Fields) > 0 || len(change.AddSections) > 0 || len(change.DeleteSections) > 0 {
+			plan.Changes = append(plan.Changes, change)
+		}
+	}
+
+	if deleteUnmanaged {
+		sort.Slice(boards, func(i, j int) bool { return boards[i].Name < boards[j].Name })
+		for _, board := range boards {
+			if _, ok := Find(specs, board.Name); ok {
+				continue
+			}
+			plan.Changes = append(plan.Changes, Change{
+				Action:    ActionDelete,
+				BoardName: board.Name,
+				BoardId:   board.Id,
+			})
+		}
+	}
+
+	return plan, nil
+}
+
+func diffSections(ctx context.Context, client pinterest.ClientInterface, change *Change, deleteUnmanaged bool) error {
+	sections, err := client.ListBoardSections(ctx, change.BoardId)
+	if err != nil {
+		return fmt.Errorf("error listing sections of board %s: %w", change.BoardName, err)
+	}
+
+	existing := map[string]bool{}
+	for _, section := range sections {
+		existing[section.Name] = true
+	}
+
+	wanted := map[string]bool{}
+	for _, name := range change.Spec.Sections {
+		wanted[name] = true
+		if !existing[name] {
+			change.AddSections = append(change.AddSections, name)
+		}
+	}
+
+	if deleteUnmanaged {
+		for _, section := range sections {
+			if !wanted[section.Name] {
+				change.DeleteSections = append(change.DeleteSections, section)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Empty reports whether the account already matches the specs.
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// Deletes reports whether applying the plan deletes boards or sections.
+func (p *Plan) Deletes() bool {
+	for _, change := range p.Changes {
+		if change.Action == ActionDelete || len(change.DeleteSections) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Print writes a human readable summary of the plan.
+func (p *Plan) Print(w io.Writer) {
+	if p.Empty() {
+		fmt.Fprintln(w, "No changes. The boards match the configuration.")
+		return
+	}
+
+	counts := map[string]int{}
+	for _, change := range p.Changes {
+		counts[change.Action]++
+
+		switch change.Action {
+		case ActionCreate:
+			fmt.Fprintf(w, "+ create board %q (privacy %s)\n", change.BoardName, change.Spec.BoardData().Privacy)
+			if change.Spec.Description != "" {
+				fmt.Fprintf(w, "    description: %q\n", change.Spec.Description)
+			}
+		case ActionUpdate:
+			fmt.Fprintf(w, "~ update board %q\n", change.BoardName)
+			for _, field := range change.Fields {
+				fmt.Fprintf(w, "    %s: %q -> %q\n", field.Field, field.From, field.To)
+			}
+		case ActionDelete:
+			fmt.Fprintf(w, "- delete board %q\n", change.BoardName)
+		}
+
+		for _, section := range change.AddSections {
+			fmt.Fprintf(w, "    + section %q\n", section)
+		}
+		for _, section := range change.DeleteSections {
+			fmt.Fprintf(w, "    - section %q\n", section.Name)
+		}
+	}
+
+	fmt.Fprintf(w, "\nPlan: %d to create, %d to update, %d to delete.\n", counts[ActionCreate], counts[ActionUpdate], counts[ActionDelete])
+}
+
+// Apply carries out the changes of the plan in order and stops at the first
+// failing change.
+func (p *Plan) Apply(ctx context.Context, client pinterest.ClientInterface, log logr.Logger) error {
+	for _, change := range p.Changes {
+		err := applyChange(ctx, client, log, change)
+		if err != nil {
+			return fmt.Errorf("error applying %s of board %s: %w", change.Action, change.BoardName, err)
+		}
+	}
+	return nil
+}
+
+func applyChange(ctx context.Context, client pinterest.ClientInterface, log logr.Logger, change Change) error {
+	boardId := change.BoardId
+
+	switch change.Action {
+	case ActionCreate:
+		board, err := client.CreateBoard(ctx, change.Spec.BoardData())
+		if err != nil {
+			return err
+		}
+		boardId = board.Id
+		log.Info(fmt.Sprintf("Created board: %s", change.BoardName))
+
+	case ActionUpdate:
+		if len(change.Fields) > 0 {
+			update := pinterest.BoardUpdate{}
+			for _, field := range change.Fields {
+				value := field.To
+				switch field.Field {
+				case "description":
+					update.Description = &value
+				case "privacy":
+					update.Privacy = &value
+				}
+			}
+			if _, err := client.UpdateBoard(ctx, boardId, update); err != nil {
+				return err
+			}
+			log.Info(fmt.Sprintf("Updated board: %s", change.BoardName))
+		}
+
+	case ActionDelete:
+		if err := client.DeleteBoard(ctx, boardId); err != nil {
+			return err
+		}
+		log.Info(fmt.Sprintf("Deleted board: %s", change.BoardName))
+		return nil
+	}
+
+	for _, name := range change.AddSections {
+		if _, err := client.CreateBoardSection(ctx, boardId, name); err != nil {
+			return fmt.Errorf("error creating section %s: %w", name, err)
+		}
+		log.Info(fmt.Sprintf("Created section %s in board %s", name, change.BoardName))
+	}
+
+	for _, section := range change.DeleteSections {
+		if err := client.DeleteBoardSection(ctx, boardId, section.Id); err != nil {
+			return fmt.Errorf("error deleting section %s: %w", section.Name, err)
+		}
+		log.Info(fmt.Sprintf("Deleted section %s of board %s", section.Name, change.BoardName))
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package boards
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"pin-creator/pinterest"
+)
+
+type fakeClient struct {
+	pinterest.ClientInterface
+	boards   []pinterest.BoardInfo
+	sections map[string][]pinterest.BoardSection
+	calls    []string
+}
+
+func (c *fakeClient) ListBoards(ctx context.Context, opts ...pinterest.ListOption) ([]pinterest.BoardInfo, error) {
+	return c.boards, nil
+}
+
+func (c *fakeClient) ListBoardSections(ctx context.Context, boardId string, opts ...pinterest.ListOption) ([]pinterest.BoardSection, error) {
+	return c.sections[boardId], nil
+}
+
+func (c *fakeClient) CreateBoard(ctx context.Context, boardData pinterest.BoardData) (*pinterest.BoardInfo, error) {
+	c.calls = append(c.calls, "create board "+boardData.Name+" "+boardData.Privacy)
+	return &pinterest.BoardInfo{Id: "new", Name: boardData.Name}, nil
+}
+
+func (c *fakeClient) UpdateBoard(ctx context.Context, boardId string, update pinterest.BoardUpdate) (*pinterest.BoardInfo, error) {
+	c.calls = append(c.calls, "update board "+boardId+" "+*update.Privacy)
+	return &pinterest.BoardInfo{Id: boardId}, nil
+}
+
+func (c *fakeClient) DeleteBoard(ctx context.Context, boardId string) error {
+	c.calls = append(c.calls, "delete board "+boardId)
+	return nil
+}
+
+func (c *fakeClient) CreateBoardSection(ctx context.Context, boardId string, name string) (*pinterest.BoardSection, error) {
+	c.calls = append(c.calls, "create section "+boardId+" "+name)
+	return &pinterest.BoardSection{Id: "s", Name: name}, nil
+}
+
+func (c *fakeClient) DeleteBoardSection(ctx context.Context, boardId string, sectionId string) error {
+	c.calls = append(c.calls, "delete section "+boardId+" "+sectionId)
+	return nil
+}
+
+func TestPlanAndApply(t *testing.T) {
+	client := &fakeClient{
+		boards: []pinterest.BoardInfo{
+			{Id: "1", Name: "recipes", Description: "Food", Privacy: "PUBLIC"},
+			{Id: "2", Name: "unchanged", Description: "Same", Privacy: "SECRET"},
+			{Id: "3", Name: "old"},
+		},
+		sections: map[string][]pinterest.BoardSection{
+			"1": {{Id: "10", Name: "dinner"}, {Id: "11", Name: "stale"}},
+		},
+	}
+
+	specs := []Spec{
+		{Name: "recipes", Description: "Food", Privacy: "SECRET", Sections: []string{"dinner", "lunch"}},
+		{Name: "unchanged", Description: "Same", Privacy: "SECRET"},
+		{Name: "travel", Privacy: "PUBLIC", Sections: []string{"europe"}},
+	}
+
+	plan, err := NewPlan(context.Background(), client, specs, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(plan.Changes))
+	assert.Equal(t, ActionUpdate, plan.Changes[0].Action)
+	assert.Equal(t, []string{"lunch"}, plan.Changes[0].AddSections)
+	assert.Empty(t, plan.Changes[0].DeleteSections)
+	assert.Equal(t, ActionCreate, plan.Changes[1].Action)
+
+	var out bytes.Buffer
+	plan.Print(&out)
+	assert.Contains(t, out.String(), "Plan: 1 to create, 1 to update, 0 to delete.")
+
+	assert.False(t, plan.Deletes())
+
+	plan, err = NewPlan(context.Background(), client, specs, true)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(plan.Changes))
+	assert.True(t, plan.Deletes())
+
+	err = plan.Apply(context.Background(), client, logr.Discard())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"update board 1 SECRET",
+		"create section 1 lunch",
+		"delete section 1 11",
+		"create board travel PUBLIC",
+		"create section new europe",
+		"delete board 3",
+	}, client.calls)
+}
+
+func TestPlanIgnoresEmptySpecFields(t *testing.T) {
+	client := &fakeClient{
+		boards: []pinterest.BoardInfo{
+			{Id: "1", Name: "recipes", Description: "Food", Privacy: "PUBLIC"},
+		},
+	}
+
+	plan, err := NewPlan(context.Background(), client, []Spec{{Name: "recipes"}}, false)
+	assert.NoError(t, err)
+	assert.True(t, plan.Empty())
+
+	plan, err = NewPlan(context.Background(), client, []Spec{{Name: "recipes", Privacy: "SECRET"}}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(plan.Changes))
+	assert.Equal(t, []FieldChange{{Field: "privacy", From: "PUBLIC", To: "SECRET"}}, plan.Changes[0].Fields)
+}
+
+func TestPlanKeepsPrivacyOmittedFromFile(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "boards.yaml")
+	err := os.WriteFile(filePath, []byte("boards:\n  - name: recipes\n  - name: travel\n"), 0o644)
+	assert.NoError(t, err)
+
+	specs, err := ReadFile(filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "", specs[0].Privacy)
+
+	client := &fakeClient{
+		boards: []pinterest.BoardInfo{
+			{Id: "1", Name: "recipes", Privacy: "SECRET"},
+		},
+	}
+
+	plan, err := NewPlan(context.Background(), client, specs, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(plan.Changes))
+	assert.Equal(t, ActionCreate, plan.Changes[0].Action)
+	assert.Equal(t, "travel", plan.Changes[0].BoardName)
+
+	err = plan.Apply(context.Background(), client, logr.Discard())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"create board travel PUBLIC"}, client.calls)
+}
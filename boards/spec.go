@@ -0,0 +1,96 @@
+package boards
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"pin-creator/pinterest"
+)
+
+// Spec describes the desired state of a board.
+type Spec struct {
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description"`
+	Privacy     string   `yaml:"privacy"`
+	Sections    []string `yaml:"sections"`
+}
+
+type specFile struct {
+	Boards []Spec `yaml:"boards"`
+}
+
+// BoardData returns the settings used to create the board. Boards without
+// privacy are created public.
+func (s Spec) BoardData() pinterest.BoardData {
+	privacy := s.Privacy
+	if privacy == "" {
+		privacy = pinterest.PrivacyPublic
+	}
+	return pinterest.BoardData{
+		Name:        s.Name,
+		Description: s.Description,
+		Privacy:     privacy,
+	}
+}
+
+// Template returns the template used when a schedule row needs the board
+// before it was created by apply.
+func (s Spec) Template() pinterest.BoardTemplate {
+	return pinterest.BoardTemplate{
+		BoardData: s.BoardData(),
+		Sections:  s.Sections,
+	}
+}
+
+// ReadFile reads the board specs of a YAML file. Fields a board leaves empty
+// stay empty so apply doesn't change them on existing boards.
+func ReadFile(filePath string) ([]Spec, error) {
+	yamlFile, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read boards file. Error: %s", err.Error())
+	}
+
+	f := specFile{}
+	err = yaml.Unmarshal(yamlFile, &f)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse boards file. Error: %s", err.Error())
+	}
+
+	names := map[string]bool{}
+	for i := range f.Boards {
+		spec := &f.Boards[i]
+		if spec.Name == "" {
+			return nil, fmt.Errorf("board without name in %s", filePath)
+		}
+		if names[spec.Name] {
+			return nil, fmt.Errorf("board %s is defined twice in %s", spec.Name, filePath)
+		}
+		names[spec.Name] = true
+
+		if spec.Privacy != "" && !pinterest.ValidPrivacy(spec.Privacy) {
+			return nil, fmt.Errorf("board %s has invalid privacy %s", spec.Name, spec.Privacy)
+		}
+
+		sections := map[string]bool{}
+		for _, section := range spec.Sections {
+			if sections[section] {
+				return nil, fmt.Errorf("section %s of board %s is defined twice", section, spec.Name)
+			}
+			sections[section] = true
+		}
+	}
+
+	return f.Boards, nil
+}
+
+// Find returns the spec of the board with the given name.
+func Find(specs []Spec, boardName string) (Spec, bool) {
+	for _, spec := range specs {
+		if spec.Name == boardName {
+			return spec, true
+		}
+	}
+	return Spec{}, false
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"pin-creator/accessToken"
+)
+
+// runAuthCommand replaces the access token of the environment by a new one
+// created in the browser.
+func runAuthCommand(ctx context.Context, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("usage: auth")
+	}
+	if dryRun() {
+		return fmt.Errorf("auth is not available in a dry run")
+	}
+
+	if err := accessToken.NewAccessTokenFileHandler(accessTokenPath(ctx)).Delete(); err != nil {
+		return fmt.Errorf("error deleting access token file: %w", err)
+	}
+	getToken(ctx)
+	return nil
+}
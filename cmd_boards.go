@@ -0,0 +1,228 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+
+	"pin-creator/boards"
+	"pin-creator/internal/logger"
+	"pin-creator/pinterest"
+)
+
+func runBoardsCommand(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing boards subcommand: list, get, update, delete, plan or apply")
+	}
+
+	switch args[0] {
+	case "list":
+		return runBoardsList(ctx, args[1:])
+	case "get":
+		return runBoardsGet(ctx, args[1:])
+	case "update":
+		return runBoardsUpdate(ctx, args[1:])
+	case "delete":
+		return runBoardsDelete(ctx, args[1:])
+	case "plan":
+		return runBoardsPlan(ctx, args[1:], false)
+	case "apply":
+		return runBoardsPlan(ctx, args[1:], true)
+	default:
+		return fmt.Errorf("unknown boards subcommand %s", args[0])
+	}
+}
+
+func runBoardsList(ctx context.Context, args []string) error {
+	client := getClient(ctx)
+	boards, err := client.ListBoards(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tPRIVACY\tPINS\tFOLLOWERS\tDESCRIPTION")
+	for _, board := range boards {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%s\n", board.Id, board.Name, board.Privacy, board.PinCount, board.FollowerCount, board.Description)
+	}
+	return w.Flush()
+}
+
+func runBoardsGet(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: boards get <board>")
+	}
+
+	client := getClient(ctx)
+	boardId, err := boardIdByName(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+
+	board, err := client.GetBoard(ctx, boardId)
+	if err != nil {
+		return err
+	}
+
+	printBoard(board)
+	return nil
+}
+
+func runBoardsUpdate(ctx context.Context, args []string) error {
+	log := logger.FromContext(ctx)
+
+	if len(args) == 0 {
+		return fmt.Errorf("usage: boards update <board> [--name name] [--description description] [--privacy PUBLIC|PROTECTED|SECRET]")
+	}
+
+	fs := flag.NewFlagSet("boards update", flag.ContinueOnError)
+	name := fs.String("name", "", "new name of the board")
+	description := fs.String("description", "", "new description of the board")
+	privacy := fs.String("privacy", "", "new privacy of the board: PUBLIC, PROTECTED or SECRET")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	update := pinterest.BoardUpdate{}
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "name":
+			update.Name = name
+		case "description":
+			update.Description = description
+		case "privacy":
+			update.Privacy = privacy
+		}
+	})
+	if update.Name == nil && update.Description == nil && update.Privacy == nil {
+		return fmt.Errorf("nothing to update")
+	}
+
+	client := getClient(ctx)
+	boardId, err := boardIdByName(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+
+	board, err := client.UpdateBoard(ctx, boardId, update)
+	if err != nil {
+		return err
+	}
+
+	log.Info(fmt.Sprintf("Updated board '%s'", args[0]))
+	printBoard(board)
+	return nil
+}
+
+func runBoardsDelete(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("boards delete", flag.ContinueOnError)
+	yes := fs.Bool("yes", false, "delete the matching boards without asking")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: boards delete [--yes] <regex>")
+	}
+
+	regex, err := regexp.Compile(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("invalid board regex: %w", err)
+	}
+
+	client := getClient(ctx)
+	if !*yes {
+		boardInfos, err := client.ListBoards(ctx)
+		if err != nil {
+			return err
+		}
+
+		matches := 0
+		for _, board := range boardInfos {
+			if regex.MatchString(board.Name) {
+				fmt.Println(board.Name)
+				matches++
+			}
+		}
+		if matches == 0 {
+			fmt.Println("No boards match")
+			return nil
+		}
+		if !confirm(fmt.Sprintf("Delete these %d boards and their pins?", matches)) {
+			return fmt.Errorf("deleting boards aborted")
+		}
+	}
+
+	return client.DeleteBoards(ctx, fs.Arg(0))
+}
+
+// confirm asks the question on stdout and reports whether it was answered with
+// yes.
+func confirm(question string) bool {
+	fmt.Printf("%s [y/N] ", question)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+func runBoardsPlan(ctx context.Context, args []string, apply bool) error {
+	log := logger.FromContext(ctx)
+
+	fs := flag.NewFlagSet("boards plan", flag.ContinueOnError)
+	file := fs.String("file", cfg.BoardsFilePath, "boards file describing the desired boards")
+	deleteUnmanaged := fs.Bool("delete", false, "delete boards and sections that aren't in the boards file")
+	yes := fs.Bool("yes", false, "apply deletions without asking")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *file == "" {
+		return fmt.Errorf("no boards file given. Set boards_file_path or pass --file")
+	}
+
+	specs, err := boards.ReadFile(*file)
+	if err != nil {
+		return err
+	}
+
+	client := getClient(ctx)
+	plan, err := boards.NewPlan(ctx, client, specs, *deleteUnmanaged)
+	if err != nil {
+		return err
+	}
+
+	plan.Print(os.Stdout)
+	if !apply || plan.Empty() {
+		return nil
+	}
+	if plan.Deletes() && !*yes && !confirm("Apply the plan and delete the boards and sections listed above?") {
+		return fmt.Errorf("applying the plan aborted")
+	}
+
+	err = plan.Apply(ctx, client, log)
+	if err != nil {
+		return err
+	}
+
+	log.Info(fmt.Sprintf("Applied %d changes", len(plan.Changes)))
+	return nil
+}
+
+func printBoard(board *pinterest.BoardInfo) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "ID:\t%s\n", board.Id)
+	fmt.Fprintf(w, "Name:\t%s\n", board.Name)
+	fmt.Fprintf(w, "Description:\t%s\n", board.Description)
+	fmt.Fprintf(w, "Privacy:\t%s\n", board.Privacy)
+	fmt.Fprintf(w, "Pins:\t%d\n", board.PinCount)
+	fmt.Fprintf(w, "Followers:\t%d\n", board.FollowerCount)
+	fmt.Fprintf(w, "Owner:\t%s\n", board.Owner.Username)
+	if !board.CreatedAt.IsZero() {
+		fmt.Fprintf(w, "Created:\t%s\n", board.CreatedAt.Format("2006-01-02 15:04:05"))
+	}
+	w.Flush()
+}
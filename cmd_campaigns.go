@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"pin-creator/schedule"
+)
+
+const campaignDateFormat = "2006-01-02"
+
+func runCampaignsCommand(ctx context.Context, args []string) error {
+	scheduleReader, campaigns, err := newScheduleReader()
+	if err != nil {
+		return err
+	}
+	if len(campaigns) == 0 {
+		return fmt.Errorf("no campaigns configured in campaigns_file_path")
+	}
+
+	rows, err := scheduleReader.All()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CAMPAIGN\tSTATE\tSTART\tEND\tBOARD\tPOSTED\tPENDING\tFAILED")
+	for _, p := range schedule.Progress(rows, campaigns) {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%d\t%d\n",
+			p.Campaign.Name,
+			p.Campaign.State(now),
+			formatCampaignDate(p.Campaign.Start),
+			formatCampaignDate(p.Campaign.End),
+			p.Campaign.DefaultBoard,
+			p.Posted,
+			p.Pending,
+			p.Failed,
+		)
+	}
+
+	return w.Flush()
+}
+
+func formatCampaignDate(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format(campaignDateFormat)
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"pin-creator/internal/logger"
+	"pin-creator/pinterest"
+)
+
+func runPinsCommand(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing pins subcommand: list, get, update or delete")
+	}
+
+	switch args[0] {
+	case "list":
+		return runPinsList(ctx, args[1:])
+	case "get":
+		return runPinsGet(ctx, args[1:])
+	case "update":
+		return runPinsUpdate(ctx, args[1:])
+	case "delete":
+		return runPinsDelete(ctx, args[1:])
+	default:
+		return fmt.Errorf("unknown pins subcommand %s", args[0])
+	}
+}
+
+func runPinsList(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("pins list", flag.ContinueOnError)
+	board := fs.String("board", "", "only list the pins of this board")
+	pageSize := fs.Int("page-size", 0, "number of pins requested per page")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	client := getClient(ctx)
+
+	boardId := ""
+	if *board != "" {
+		var err error
+		boardId, err = boardIdByName(ctx, client, *board)
+		if err != nil {
+			return err
+		}
+	}
+
+	pins, err := client.ListPins(ctx, boardId, pinterest.WithPageSize(*pageSize))
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tBOARD\tCREATED\tTITLE\tLINK")
+	for _, pin := range pins {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", pin.ID, pin.BoardID, pin.CreatedAt.Format("2006-01-02 15:04"), pin.Title, pin.Link)
+	}
+	return w.Flush()
+}
+
+func runPinsGet(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: pins get <pin id>")
+	}
+
+	client := getClient(ctx)
+	pin, err := client.GetPin(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	printPin(pin)
+	return nil
+}
+
+func runPinsUpdate(ctx context.Context, args []string) error {
+	log := logger.FromContext(ctx)
+
+	if len(args) == 0 {
+		return fmt.Errorf("usage: pins update <pin id> [--title title] [--description description] [--link link] [--alt-text text] [--board board] [--section section]")
+	}
+
+	fs := flag.NewFlagSet("pins update", flag.ContinueOnError)
+	title := fs.String("title", "", "new title of the pin")
+	description := fs.String("description", "", "new description of the pin")
+	link := fs.String("link", "", "new link of the pin")
+	altText := fs.String("alt-text", "", "new alt text of the pin")
+	board := fs.String("board", "", "board to move the pin to")
+	section := fs.String("section", "", "section of the board to move the pin to")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	update := pinterest.PinUpdate{}
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "title":
+			update.Title = title
+		case "description":
+			update.Description = description
+		case "link":
+			update.Link = link
+		case "alt-text":
+			update.AltText = altText
+		}
+	})
+
+	client := getClient(ctx)
+
+	if *section != "" && *board == "" {
+		pin, err := client.GetPin(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		update.BoardId = &pin.BoardID
+	}
+	if *board != "" {
+		boardId, err := boardIdByName(ctx, client, *board)
+		if err != nil {
+			return err
+		}
+		update.BoardId = &boardId
+	}
+	if *section != "" {
+		sectionId, err := sectionIdByName(ctx, client, *update.BoardId, *section)
+		if err != nil {
+			return err
+		}
+		update.BoardSectionId = &sectionId
+	}
+
+	if update == (pinterest.PinUpdate{}) {
+		return fmt.Errorf("nothing to update")
+	}
+
+	pin, err := client.UpdatePin(ctx, args[0], update)
+	if err != nil {
+		return err
+	}
+
+	log.Info(fmt.Sprintf("Updated pin %s", pin.ID))
+	printPin(pin)
+	return nil
+}
+
+func runPinsDelete(ctx context.Context, args []string) error {
+	log := logger.FromContext(ctx)
+
+	if len(args) != 1 {
+		return fmt.Errorf("usage: pins delete <pin id>")
+	}
+
+	client := getClient(ctx)
+	err := client.DeletePin(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	log.Info(fmt.Sprintf("Deleted pin %s", args[0]))
+	return nil
+}
+
+func printPin(pin *pinterest.Pin) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "ID:\t%s\n", pin.ID)
+	fmt.Fprintf(w, "Title:\t%s\n", pin.Title)
+	fmt.Fprintf(w, "Description:\t%s\n", pin.Description)
+	fmt.Fprintf(w, "Link:\t%s\n", pin.Link)
+	fmt.Fprintf(w, "Alt text:\t%s\n", pin.AltText)
+	fmt.Fprintf(w, "Board:\t%s\n", pin.BoardID)
+	if pin.BoardSectionID != nil {
+		fmt.Fprintf(w, "Section:\t%s\n", *pin.BoardSectionID)
+	}
+	fmt.Fprintf(w, "Created:\t%s\n", pin.CreatedAt.Format("2006-01-02 15:04:05"))
+	if pin.PinMetrics != nil {
+		metrics := pin.PinMetrics.AllTime()
+		fmt.Fprintf(w, "Impressions:\t%d\n", metrics.Impression)
+		fmt.Fprintf(w, "Pin clicks:\t%d\n", metrics.PinClick)
+		fmt.Fprintf(w, "Outbound clicks:\t%d\n", metrics.Clickthrough)
+	}
+	w.Flush()
+}
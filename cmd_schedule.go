@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"pin-creator/internal/logger"
+	"pin-creator/schedule"
+)
+
+const formatICS = "ics"
+
+func runScheduleCommand(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing schedule subcommand: export or import")
+	}
+
+	switch args[0] {
+	case "export":
+		return runScheduleExport(ctx, args[1:])
+	case "import":
+		return runScheduleImport(ctx, args[1:])
+	default:
+		return fmt.Errorf("unknown schedule subcommand %s", args[0])
+	}
+}
+
+func runScheduleExport(ctx context.Context, args []string) error {
+	log := logger.FromContext(ctx)
+
+	fs := flag.NewFlagSet("schedule export", flag.ContinueOnError)
+	format := fs.String("format", formatICS, "export format (ics)")
+	output := fs.String("output", "", "file to write to (default stdout)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *format != formatICS {
+		return fmt.Errorf("unsupported export format %s", *format)
+	}
+
+	scheduleReader, campaigns, err := newScheduleReader()
+	if err != nil {
+		return err
+	}
+
+	rows, err := scheduleReader.All()
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return fmt.Errorf("unable to create %s: %w", *output, err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	err = schedule.ExportICS(w, rows, campaigns)
+	if err != nil {
+		return fmt.Errorf("unable to export schedule: %w", err)
+	}
+
+	if *output != "" {
+		log.Info(fmt.Sprintf("Exported %d rows to %s", len(rows), *output))
+	}
+	return nil
+}
+
+func runScheduleImport(ctx context.Context, args []string) error {
+	log := logger.FromContext(ctx)
+
+	fs := flag.NewFlagSet("schedule import", flag.ContinueOnError)
+	format := fs.String("format", formatICS, "import format (ics)")
+	board := fs.String("board", "", "board for events that do not name one")
+	image := fs.String("image", "", "image path for events that do not name one")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *format != formatICS {
+		return fmt.Errorf("unsupported import format %s", *format)
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("expected exactly one file to import")
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("unable to open %s: %w", fs.Arg(0), err)
+	}
+	defer f.Close()
+
+	rows, err := schedule.ImportICS(f, *board, *image)
+	if err != nil {
+		return fmt.Errorf("unable to import %s: %w", fs.Arg(0), err)
+	}
+
+	err = schedule.NewScheduleReader(cfg.ScheduleFilePath).Append(rows)
+	if err != nil {
+		return err
+	}
+
+	log.Info(fmt.Sprintf("Imported %d rows into %s", len(rows), cfg.ScheduleFilePath))
+	return nil
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"pin-creator/internal/logger"
+	"pin-creator/pinterest"
+)
+
+func runSectionsCommand(ctx context.Context, args []string) error {
+	log := logger.FromContext(ctx)
+
+	if len(args) < 2 {
+		return fmt.Errorf("usage: sections list|create|rename|delete <board> [section] [new name]")
+	}
+
+	client := getClient(ctx)
+	boardId, err := boardIdByName(ctx, client, args[1])
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "list":
+		sections, err := client.ListBoardSections(ctx, boardId)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME")
+		for _, section := range sections {
+			fmt.Fprintf(w, "%s\t%s\n", section.Id, section.Name)
+		}
+		return w.Flush()
+
+	case "create":
+		if len(args) != 3 {
+			return fmt.Errorf("usage: sections create <board> <section>")
+		}
+		section, err := client.CreateBoardSection(ctx, boardId, args[2])
+		if err != nil {
+			return err
+		}
+		log.Info(fmt.Sprintf("Created section '%s' with id %s", section.Name, section.Id))
+
+	case "rename":
+		if len(args) != 4 {
+			return fmt.Errorf("usage: sections rename <board> <section> <new name>")
+		}
+		sectionId, err := sectionIdByName(ctx, client, boardId, args[2])
+		if err != nil {
+			return err
+		}
+		section, err := client.RenameBoardSection(ctx, boardId, sectionId, args[3])
+		if err != nil {
+			return err
+		}
+		log.Info(fmt.Sprintf("Renamed section '%s' to '%s'", args[2], section.Name))
+
+		if cache := getBoardCache(ctx); cache != nil {
+			if err := cache.RenameSection(args[1], args[2], section.Name, section.Id); err != nil {
+				return err
+			}
+		}
+
+	case "delete":
+		if len(args) != 3 {
+			return fmt.Errorf("usage: sections delete <board> <section>")
+		}
+		sectionId, err := sectionIdByName(ctx, client, boardId, args[2])
+		if err != nil {
+			return err
+		}
+		err = client.DeleteBoardSection(ctx, boardId, sectionId)
+		if err != nil {
+			return err
+		}
+		log.Info(fmt.Sprintf("Deleted section '%s'", args[2]))
+
+		if cache := getBoardCache(ctx); cache != nil {
+			if err := cache.InvalidateSection(args[1], args[2]); err != nil {
+				return err
+			}
+		}
+
+	default:
+		return fmt.Errorf("unknown sections subcommand %s", args[0])
+	}
+
+	return nil
+}
+
+func boardIdByName(ctx context.Context, client pinterest.ClientInterface, boardName string) (string, error) {
+	boards, err := client.ListBoards(ctx)
+	if err != nil {
+		return "", err
+	}
+	return pinterest.BoardIdByName(boards, boardName)
+}
+
+func sectionIdByName(ctx context.Context, client pinterest.ClientInterface, boardId string, sectionName string) (string, error) {
+	sections, err := client.ListBoardSections(ctx, boardId)
+	if err != nil {
+		return "", err
+	}
+
+	for _, section := range sections {
+		if section.Name == sectionName {
+			return section.Id, nil
+		}
+	}
+
+	return "", pinterest.ErrBoardSectionNotFound{SectionName: sectionName}
+}
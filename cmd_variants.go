@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"pin-creator/internal/logger"
+	"pin-creator/pinterest"
+	"pin-creator/schedule"
+)
+
+type variantPerformance struct {
+	pins    int
+	metrics pinterest.MetricsData
+}
+
+func runVariantsCommand(ctx context.Context, args []string) error {
+	log := logger.FromContext(ctx)
+
+	rows, err := schedule.NewScheduleReader(cfg.ScheduleFilePath).All()
+	if err != nil {
+		return err
+	}
+
+	client := getClient(ctx)
+	performance := map[int]*variantPerformance{}
+	maxVariants := 0
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ROW\tVARIANT\tPIN\tTITLE\tIMPRESSIONS\tPIN CLICKS\tOUTBOUND CLICKS\tCTR")
+	for _, row := range rows {
+		if !row.Created || row.PinId == "" || len(row.Variants) < 2 || row.Variant >= len(row.Variants) {
+			continue
+		}
+
+		pin, err := client.GetPin(ctx, row.PinId)
+		if err != nil {
+			log.Error(err, fmt.Sprintf("error fetching metrics of pin %s", row.PinId))
+			continue
+		}
+
+		metrics := pin.PinMetrics.AllTime()
+		p, ok := performance[row.Variant]
+		if !ok {
+			p = &variantPerformance{}
+			performance[row.Variant] = p
+		}
+		p.pins++
+		p.metrics.Impression += metrics.Impression
+		p.metrics.PinClick += metrics.PinClick
+		p.metrics.Clickthrough += metrics.Clickthrough
+
+		if len(row.Variants) > maxVariants {
+			maxVariants = len(row.Variants)
+		}
+
+		fmt.Fprintf(w, "%d\t%d\t%s\t%s\t%d\t%d\t%d\t%s\n",
+			row.Index,
+			row.Variant,
+			row.PinId,
+			row.Variants[row.Variant].Title,
+			metrics.Impression,
+			metrics.PinClick,
+			metrics.Clickthrough,
+			clickThroughRate(metrics),
+		)
+	}
+	w.Flush()
+
+	if len(performance) == 0 {
+		fmt.Println("No posted rows with variants found")
+		return nil
+	}
+
+	fmt.Println()
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VARIANT\tPINS\tIMPRESSIONS\tPIN CLICKS\tOUTBOUND CLICKS\tCTR")
+	for i := 0; i < maxVariants; i++ {
+		p, ok := performance[i]
+		if !ok {
+			p = &variantPerformance{}
+		}
+		fmt.Fprintf(w, "%d\t%d\t%d\t%d\t%d\t%s\n",
+			i,
+			p.pins,
+			p.metrics.Impression,
+			p.metrics.PinClick,
+			p.metrics.Clickthrough,
+			clickThroughRate(p.metrics),
+		)
+	}
+
+	return w.Flush()
+}
+
+func clickThroughRate(metrics pinterest.MetricsData) string {
+	if metrics.Impression == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f%%", float64(metrics.Clickthrough)/float64(metrics.Impression)*100)
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+)
+
+type command struct {
+	usage string
+	help  string
+	run   func(ctx context.Context, args []string) error
+}
+
+var commands = map[string]command{
+	"auth": {
+		usage: "auth",
+		help:  "create a new access token in the browser",
+		run:   runAuthCommand,
+	},
+	"boards": {
+		usage: "boards list|get|update|delete|plan|apply ...",
+		help:  "show, edit and reconcile boards",
+		run:   runBoardsCommand,
+	},
+	"campaigns": {
+		usage: "campaigns",
+		help:  "show the progress of every campaign",
+		run:   runCampaignsCommand,
+	},
+	"pins": {
+		usage: "pins list|get|update|delete ...",
+		help:  "show, edit and delete pins",
+		run:   runPinsCommand,
+	},
+	"schedule": {
+		usage: "schedule export|import ...",
+		help:  "export the schedule to or import it from another format",
+		run:   runScheduleCommand,
+	},
+	"sections": {
+		usage: "sections list|create|rename|delete <board> ...",
+		help:  "manage the sections of a board",
+		run:   runSectionsCommand,
+	},
+	"variants": {
+		usage: "variants",
+		help:  "compare the performance of title and description variants",
+		run:   runVariantsCommand,
+	},
+}
+
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "Usage: %s [flags] config.yaml [command]\n\n", os.Args[0])
+	fmt.Fprintln(out, "Without a command the next scheduled pin is created.")
+	fmt.Fprintln(out, "\nCommands:")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %s\t%s\n", commands[name].usage, commands[name].help)
+	}
+	w.Flush()
+
+	fmt.Fprintln(out, "\nFlags:")
+	flag.PrintDefaults()
+}
+
+func runCommand(ctx context.Context, args []string) error {
+	cmd, ok := commands[args[0]]
+	if !ok {
+		flag.Usage()
+		return fmt.Errorf("unknown command %s", args[0])
+	}
+
+	return cmd.run(ctx, args[1:])
+}
@@ -2,15 +2,91 @@ package config
 
 import (
 	"os"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
 type Config struct {
-	AccessTokenPath  string `yaml:"access_token_path"`
-	ScheduleFilePath string `yaml:"schedule_file_path"`
-	BrowserPath      string `yaml:"browser_path"`
-	RedirectPort     int    `yaml:"redirect_port"`
+	AccessTokenPath      string                   `yaml:"access_token_path"`
+	Environment          string                   `yaml:"environment"`
+	ApiBaseUrl           string                   `yaml:"api_base_url"`
+	ScheduleFilePath     string                   `yaml:"schedule_file_path"`
+	CampaignsFilePath    string                   `yaml:"campaigns_file_path"`
+	VariantStrategy      string                   `yaml:"variant_strategy"`
+	VariantSeed          int64                    `yaml:"variant_seed"`
+	ImageConversion      string                   `yaml:"image_conversion"`
+	DownloadImageUrls    bool                     `yaml:"download_image_urls"`
+	ImageProcessing      *ImageProcessing         `yaml:"image_processing"`
+	TemplatesFilePath    string                   `yaml:"templates_file_path"`
+	TemplateCacheDir     string                   `yaml:"template_cache_dir"`
+	BoardsFilePath       string                   `yaml:"boards_file_path"`
+	BoardDefaults        BoardSettings            `yaml:"board_defaults"`
+	BoardOverrides       map[string]BoardSettings `yaml:"board_overrides"`
+	DisableBoardCreation bool                     `yaml:"disable_board_creation"`
+	BoardCachePath       string                   `yaml:"board_cache_path"`
+	BoardCacheTTL        time.Duration            `yaml:"board_cache_ttl"`
+	RateLimit            RateLimit                `yaml:"rate_limit"`
+	Retry                Retry                    `yaml:"retry"`
+	BrowserPath          string                   `yaml:"browser_path"`
+	RedirectPort         int                      `yaml:"redirect_port"`
+}
+
+// ImageProcessing prepares local images before they are uploaded. Images are
+// only processed if it's configured.
+type ImageProcessing struct {
+	AspectRatio string `yaml:"aspect_ratio"`
+	FocalPoint  string `yaml:"focal_point"`
+	MaxWidth    int    `yaml:"max_width"`
+	Format      string `yaml:"format"`
+	Quality     int    `yaml:"quality"`
+	MaxBytes    int    `yaml:"max_bytes"`
+	CacheDir    string `yaml:"cache_dir"`
+}
+
+// RateLimit configures the client side rate limit per endpoint category, e.g.
+// pins_write, or per read and write.
+type RateLimit struct {
+	PerMinute map[string]int `yaml:"per_minute"`
+	FailFast  bool           `yaml:"fail_fast"`
+	MaxWait   time.Duration  `yaml:"max_wait"`
+}
+
+// Retry configures the retries of failed requests.
+type Retry struct {
+	Disabled        bool          `yaml:"disabled"`
+	MaxRetries      int           `yaml:"max_retries"`
+	InitialInterval time.Duration `yaml:"initial_interval"`
+	MaxInterval     time.Duration `yaml:"max_interval"`
+}
+
+// BoardSettings are used when a board named in the schedule doesn't exist and
+// gets created. Empty fields fall back to the next less specific setting.
+type BoardSettings struct {
+	Description string   `yaml:"description"`
+	Privacy     string   `yaml:"privacy"`
+	Sections    []string `yaml:"sections"`
+}
+
+// BoardSettingsFor merges the override of the board over the board defaults.
+func (c *Config) BoardSettingsFor(boardName string) BoardSettings {
+	settings := c.BoardDefaults
+
+	override, ok := c.BoardOverrides[boardName]
+	if !ok {
+		return settings
+	}
+
+	if override.Description != "" {
+		settings.Description = override.Description
+	}
+	if override.Privacy != "" {
+		settings.Privacy = override.Privacy
+	}
+	if override.Sections != nil {
+		settings.Sections = override.Sections
+	}
+	return settings
 }
 
 type ConfigReader struct {
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoardSettingsFor(t *testing.T) {
+	cfg := &Config{
+		BoardDefaults: BoardSettings{
+			Description: "Default description",
+			Privacy:     "PUBLIC",
+			Sections:    []string{"default"},
+		},
+		BoardOverrides: map[string]BoardSettings{
+			"secret":      {Privacy: "SECRET"},
+			"described":   {Description: "Own description", Sections: []string{"a", "b"}},
+			"no sections": {Sections: []string{}},
+		},
+	}
+
+	tests := []struct {
+		board    string
+		expected BoardSettings
+	}{
+		{"other", BoardSettings{Description: "Default description", Privacy: "PUBLIC", Sections: []string{"default"}}},
+		{"secret", BoardSettings{Description: "Default description", Privacy: "SECRET", Sections: []string{"default"}}},
+		{"described", BoardSettings{Description: "Own description", Privacy: "PUBLIC", Sections: []string{"a", "b"}}},
+		{"no sections", BoardSettings{Description: "Default description", Privacy: "PUBLIC", Sections: []string{}}},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, cfg.BoardSettingsFor(test.board), test.board)
+	}
+
+	assert.Equal(t, BoardSettings{}, (&Config{}).BoardSettingsFor("other"))
+}
+
+func TestReadBoardSettings(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(filePath, []byte(`disable_board_creation: true
+board_defaults:
+  privacy: SECRET
+board_overrides:
+  recipes:
+    description: Food
+    sections: [dinner]
+`), 0o644)
+	assert.NoError(t, err)
+
+	cfg, err := NewReader(filePath).Read()
+	assert.NoError(t, err)
+	assert.True(t, cfg.DisableBoardCreation)
+	assert.Equal(t, BoardSettings{Description: "Food", Privacy: "SECRET", Sections: []string{"dinner"}}, cfg.BoardSettingsFor("recipes"))
+}
@@ -0,0 +1,75 @@
+package imageproc
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"strconv"
+	"strings"
+)
+
+type focalPoint struct {
+	x, y float64
+}
+
+var namedFocalPoints = map[string]focalPoint{
+	"":       {0.5, 0.5},
+	"center": {0.5, 0.5},
+	"top":    {0.5, 0},
+	"bottom": {0.5, 1},
+	"left":   {0, 0.5},
+	"right":  {1, 0.5},
+}
+
+func parseFocalPoint(value string) (focalPoint, error) {
+	if fp, ok := namedFocalPoints[value]; ok {
+		return fp, nil
+	}
+
+	parts := strings.Split(value, ",")
+	if len(parts) == 2 {
+		x, errX := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+		y, errY := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if errX == nil && errY == nil && x >= 0 && x <= 1 && y >= 0 && y <= 1 {
+			return focalPoint{x, y}, nil
+		}
+	}
+
+	return focalPoint{}, fmt.Errorf("invalid focal point %s", value)
+}
+
+// parseAspectRatio parses width:height into width divided by height.
+func parseAspectRatio(value string) (float64, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) == 2 {
+		width, errW := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+		height, errH := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if errW == nil && errH == nil && width > 0 && height > 0 {
+			return width / height, nil
+		}
+	}
+
+	return 0, fmt.Errorf("invalid aspect ratio %s", value)
+}
+
+// crop cuts the image to the aspect ratio, keeping as much of it as possible.
+// The focal point decides where the image is cut.
+func crop(img image.Image, ratio float64, fp focalPoint) image.Image {
+	b := img.Bounds()
+	width, height := b.Dx(), b.Dy()
+
+	rect := b
+	if float64(width)/float64(height) > ratio {
+		cropWidth := int(float64(height)*ratio + 0.5)
+		x := b.Min.X + int(float64(width-cropWidth)*fp.x)
+		rect = image.Rect(x, b.Min.Y, x+cropWidth, b.Max.Y)
+	} else {
+		cropHeight := int(float64(width)/ratio + 0.5)
+		y := b.Min.Y + int(float64(height-cropHeight)*fp.y)
+		rect = image.Rect(b.Min.X, y, b.Max.X, y+cropHeight)
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, rect.Dx(), rect.Dy()))
+	draw.Draw(dst, dst.Bounds(), img, rect.Min, draw.Src)
+	return dst
+}
@@ -0,0 +1,190 @@
+package imageproc
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+
+	_ "golang.org/x/image/bmp"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/tiff"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+
+	defaultQuality = 85
+	minQuality     = 50
+	// downscaleFactor shrinks images that are still too large at the minimum
+	// quality.
+	downscaleFactor = 0.9
+)
+
+// Options configure the processing of pin images. Zero values disable a step,
+// except Format which defaults to JPEG.
+type Options struct {
+	// AspectRatio is width:height, e.g. 2:3. Images are cropped to it.
+	AspectRatio string
+	// FocalPoint is the part of the image kept when cropping: center, top,
+	// bottom, left, right or x,y as fractions of the width and height.
+	FocalPoint string
+	MaxWidth   int
+	Format     string
+	Quality    int
+	// MaxBytes caps the size of the encoded image. The quality is lowered and
+	// the image scaled down until it fits.
+	MaxBytes int
+	CacheDir string
+}
+
+// Processor crops, scales and re-encodes images. The EXIF orientation is
+// applied first, since re-encoding drops all metadata like EXIF and GPS data.
+// Processed images are cached by the hash of the original image and the
+// options.
+type Processor struct {
+	opts       Options
+	ratio      float64
+	focalPoint focalPoint
+}
+
+func NewProcessor(opts Options) (*Processor, error) {
+	if opts.Format == "" {
+		opts.Format = FormatJPEG
+	}
+	if opts.Format != FormatJPEG && opts.Format != FormatPNG {
+		return nil, fmt.Errorf("unknown image format %s", opts.Format)
+	}
+	if opts.Quality == 0 {
+		opts.Quality = defaultQuality
+	}
+	if opts.Quality < 1 || opts.Quality > 100 {
+		return nil, fmt.Errorf("image quality %d is not between 1 and 100", opts.Quality)
+	}
+	if opts.CacheDir == "" {
+		return nil, fmt.Errorf("image processing needs a cache directory")
+	}
+
+	p := &Processor{opts: opts}
+
+	var err error
+	if opts.AspectRatio != "" {
+		p.ratio, err = parseAspectRatio(opts.AspectRatio)
+		if err != nil {
+			return nil, err
+		}
+	}
+	p.focalPoint, err = parseFocalPoint(opts.FocalPoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Process returns the path of the processed image.
+func (p *Processor) Process(imgPath string) (string, error) {
+	original, err := os.ReadFile(imgPath)
+	if err != nil {
+		return "", fmt.Errorf("unable to read image: %w", err)
+	}
+
+	processedPath := filepath.Join(p.opts.CacheDir, p.cacheKey(original)+"."+p.opts.Format)
+	if _, err := os.Stat(processedPath); err == nil {
+		return processedPath, nil
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(original))
+	if err != nil {
+		return "", fmt.Errorf("unable to decode image %s: %w", imgPath, err)
+	}
+	// re-encoding drops the EXIF orientation, so it is applied to the pixels
+	img = orient(img, exifOrientation(original))
+
+	if p.ratio != 0 {
+		img = crop(img, p.ratio, p.focalPoint)
+	}
+	if p.opts.MaxWidth > 0 && img.Bounds().Dx() > p.opts.MaxWidth {
+		img = scale(img, float64(p.opts.MaxWidth)/float64(img.Bounds().Dx()))
+	}
+
+	encoded, err := p.encode(img)
+	if err != nil {
+		return "", fmt.Errorf("unable to encode image %s: %w", imgPath, err)
+	}
+
+	if err := os.MkdirAll(p.opts.CacheDir, 0o755); err != nil {
+		return "", fmt.Errorf("unable to create image cache: %w", err)
+	}
+	if err := os.WriteFile(processedPath, encoded, 0o644); err != nil {
+		return "", fmt.Errorf("unable to write processed image: %w", err)
+	}
+
+	return processedPath, nil
+}
+
+func (p *Processor) cacheKey(original []byte) string {
+	h := sha256.New()
+	h.Write(original)
+	fmt.Fprintf(h, "%s|%s|%d|%s|%d|%d", p.opts.AspectRatio, p.opts.FocalPoint, p.opts.MaxWidth, p.opts.Format, p.opts.Quality, p.opts.MaxBytes)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// encode encodes the image and lowers the quality, then the size, until it fits
+// into MaxBytes.
+func (p *Processor) encode(img image.Image) ([]byte, error) {
+	quality := p.opts.Quality
+	for {
+		var buf bytes.Buffer
+		var err error
+		if p.opts.Format == FormatPNG {
+			err = png.Encode(&buf, img)
+		} else {
+			err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality})
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if p.opts.MaxBytes <= 0 || buf.Len() <= p.opts.MaxBytes {
+			return buf.Bytes(), nil
+		}
+
+		if p.opts.Format == FormatJPEG && quality > minQuality {
+			quality -= 10
+			if quality < minQuality {
+				quality = minQuality
+			}
+			continue
+		}
+
+		if img.Bounds().Dx() <= 1 || img.Bounds().Dy() <= 1 {
+			return nil, fmt.Errorf("image doesn't fit into %d bytes", p.opts.MaxBytes)
+		}
+		img = scale(img, downscaleFactor)
+	}
+}
+
+func scale(img image.Image, factor float64) image.Image {
+	b := img.Bounds()
+	width := int(float64(b.Dx()) * factor)
+	height := int(float64(b.Dy()) * factor)
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, b, draw.Src, nil)
+	return dst
+}
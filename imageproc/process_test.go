@@ -0,0 +1,138 @@
+package imageproc
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCrop(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 300, 300))
+	img.Set(0, 0, color.RGBA{R: 255, A: 255})
+
+	cropped := crop(img, 2.0/3.0, focalPoint{0, 0.5})
+	assert.Equal(t, image.Rect(0, 0, 200, 300), cropped.Bounds())
+	assert.Equal(t, color.RGBA{R: 255, A: 255}, cropped.At(0, 0))
+
+	cropped = crop(img, 2.0/3.0, focalPoint{1, 0.5})
+	assert.Equal(t, image.Rect(0, 0, 200, 300), cropped.Bounds())
+	assert.Equal(t, color.RGBA{}, cropped.At(0, 0))
+
+	cropped = crop(img, 2, focalPoint{0.5, 0.5})
+	assert.Equal(t, image.Rect(0, 0, 300, 150), cropped.Bounds())
+}
+
+func TestProcess(t *testing.T) {
+	imgPath := filepath.Join(t.TempDir(), "image.png")
+	f, err := os.Create(imgPath)
+	assert.NoError(t, err)
+	img := image.NewRGBA(image.Rect(0, 0, 1200, 1200))
+	for x := 0; x < 1200; x++ {
+		img.Set(x, x, color.RGBA{R: uint8(x), G: uint8(x / 5), B: 255, A: 255})
+	}
+	assert.NoError(t, png.Encode(f, img))
+	assert.NoError(t, f.Close())
+
+	cacheDir := filepath.Join(t.TempDir(), "cache")
+	p, err := NewProcessor(Options{AspectRatio: "2:3", MaxWidth: 400, MaxBytes: 20000, CacheDir: cacheDir})
+	assert.NoError(t, err)
+
+	processedPath, err := p.Process(imgPath)
+	assert.NoError(t, err)
+	assert.Equal(t, cacheDir, filepath.Dir(processedPath))
+	assert.Equal(t, ".jpeg", filepath.Ext(processedPath))
+
+	processed, err := os.Open(processedPath)
+	assert.NoError(t, err)
+	defer processed.Close()
+	config, format, err := image.DecodeConfig(processed)
+	assert.NoError(t, err)
+	assert.Equal(t, "jpeg", format)
+	assert.Equal(t, 400, config.Width)
+	assert.Equal(t, 600, config.Height)
+
+	info, err := processed.Stat()
+	assert.NoError(t, err)
+	assert.True(t, info.Size() <= 20000)
+
+	cachedPath, err := p.Process(imgPath)
+	assert.NoError(t, err)
+	assert.Equal(t, processedPath, cachedPath)
+
+	_, err = NewProcessor(Options{AspectRatio: "2x3", CacheDir: cacheDir})
+	assert.EqualError(t, err, "invalid aspect ratio 2x3")
+	_, err = NewProcessor(Options{FocalPoint: "middle", CacheDir: cacheDir})
+	assert.EqualError(t, err, "invalid focal point middle")
+}
+
+// writeOrientedJpeg writes a JPEG whose left half is red and right half blue,
+// with the EXIF orientation inserted after the start of image marker.
+func writeOrientedJpeg(t *testing.T, path string, orientation byte) {
+	img := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 40; x++ {
+			c := color.RGBA{R: 255, A: 255}
+			if x >= 20 {
+				c = color.RGBA{B: 255, A: 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	assert.NoError(t, jpeg.Encode(&buf, img, &jpeg.Options{Quality: 100}))
+
+	tiff := []byte{'M', 'M', 0, 42, 0, 0, 0, 8, 0, 1, 0x01, 0x12, 0, 3, 0, 0, 0, 1, 0, orientation, 0, 0, 0, 0, 0, 0}
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := append([]byte{0xFF, 0xE1, 0, byte(len(segment) + 2)}, segment...)
+
+	data := append(append([]byte{}, buf.Bytes()[:2]...), app1...)
+	data = append(data, buf.Bytes()[2:]...)
+	assert.NoError(t, os.WriteFile(path, data, 0o644))
+}
+
+func TestProcessAppliesExifOrientation(t *testing.T) {
+	imgPath := filepath.Join(t.TempDir(), "photo.jpg")
+	writeOrientedJpeg(t, imgPath, 6)
+
+	original, err := os.ReadFile(imgPath)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, exifOrientation(original))
+
+	p, err := NewProcessor(Options{Format: FormatPNG, CacheDir: t.TempDir()})
+	assert.NoError(t, err)
+	processedPath, err := p.Process(imgPath)
+	assert.NoError(t, err)
+
+	f, err := os.Open(processedPath)
+	assert.NoError(t, err)
+	defer f.Close()
+	processed, _, err := image.Decode(f)
+	assert.NoError(t, err)
+
+	// rotated clockwise, the left half ends up on top
+	assert.Equal(t, image.Rect(0, 0, 20, 40), processed.Bounds())
+	r, _, b, _ := processed.At(10, 5).RGBA()
+	assert.True(t, r > 0xC000 && b < 0x4000)
+	r, _, b, _ = processed.At(10, 35).RGBA()
+	assert.True(t, b > 0xC000 && r < 0x4000)
+}
+
+func TestOrient(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 3, 2))
+	img.Set(0, 0, color.RGBA{R: 255, A: 255})
+
+	for orientation, corner := range map[int]image.Point{
+		1: {0, 0}, 2: {2, 0}, 3: {2, 1}, 4: {0, 1},
+		5: {0, 0}, 6: {1, 0}, 7: {1, 2}, 8: {0, 2},
+	} {
+		oriented := orient(img, orientation)
+		assert.Equal(t, color.RGBA{R: 255, A: 255}, oriented.At(corner.X, corner.Y), "orientation %d", orientation)
+	}
+}
@@ -0,0 +1,393 @@
+package imageproc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+	"gopkg.in/yaml.v3"
+)
+
+// TemplatePrefix marks schedule images that are rendered from a template, e.g.
+// template:sale.
+const TemplatePrefix = "template:"
+
+const (
+	defaultTemplateWidth  = 1000
+	defaultTemplateHeight = 1500
+	defaultFontSize       = 64
+	defaultLineHeight     = 1.2
+)
+
+// Template describes a pin image. The title is rendered into the text box on
+// top of the background, rectangles and logo. Colors are #rrggbb, #rrggbbaa or
+// the name of a brand color.
+type Template struct {
+	Name            string            `yaml:"name"`
+	Width           int               `yaml:"width"`
+	Height          int               `yaml:"height"`
+	Background      string            `yaml:"background"`
+	BackgroundImage string            `yaml:"background_image"`
+	Colors          map[string]string `yaml:"colors"`
+	Rects           []TemplateRect    `yaml:"rects"`
+	Logo            *TemplateLogo     `yaml:"logo"`
+	Title           TemplateText      `yaml:"title"`
+}
+
+// TemplateRect is a filled rectangle, e.g. a band in a brand color.
+type TemplateRect struct {
+	Box   `yaml:",inline"`
+	Color string `yaml:"color"`
+}
+
+// TemplateLogo is scaled to the width of its box, keeping its aspect ratio.
+// Without width the logo keeps its own size.
+type TemplateLogo struct {
+	Path string `yaml:"path"`
+	Box  `yaml:",inline"`
+}
+
+// TemplateText wraps the text into its box. Lines that don't fit into the box
+// are dropped. Without font the Go font is used.
+type TemplateText struct {
+	Box        `yaml:",inline"`
+	Font       string  `yaml:"font"`
+	Size       float64 `yaml:"size"`
+	Color      string  `yaml:"color"`
+	Align      string  `yaml:"align"`
+	LineHeight float64 `yaml:"line_height"`
+}
+
+type Box struct {
+	X      int `yaml:"x"`
+	Y      int `yaml:"y"`
+	Width  int `yaml:"width"`
+	Height int `yaml:"height"`
+}
+
+type templateFile struct {
+	Templates []Template `yaml:"templates"`
+}
+
+// IsTemplate reports whether the schedule image is rendered from a template
+// and returns the template name.
+func IsTemplate(imgPath string) (string, bool) {
+	if !strings.HasPrefix(imgPath, TemplatePrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(imgPath, TemplatePrefix), true
+}
+
+func ReadTemplates(filePath string) ([]Template, error) {
+	yamlFile, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read template file. Error: %s", err.Error())
+	}
+
+	f := templateFile{}
+	err = yaml.Unmarshal(yamlFile, &f)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse template file. Error: %s", err.Error())
+	}
+
+	names := map[string]bool{}
+	for _, t := range f.Templates {
+		if t.Name == "" {
+			return nil, fmt.Errorf("template without name in %s", filePath)
+		}
+		if names[t.Name] {
+			return nil, fmt.Errorf("template %s is defined twice in %s", t.Name, filePath)
+		}
+		names[t.Name] = true
+	}
+
+	return f.Templates, nil
+}
+
+func FindTemplate(templates []Template, name string) (Template, bool) {
+	for _, t := range templates {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return Template{}, false
+}
+
+// RenderToFile renders the template with the title as PNG into the cache
+// directory and returns its path. Images are only rendered again if the
+// template, the files it uses or the title changed.
+func RenderToFile(t Template, title string, cacheDir string) (string, error) {
+	key, err := t.cacheKey(title)
+	if err != nil {
+		return "", err
+	}
+	renderedPath := filepath.Join(cacheDir, t.Name+"-"+key+".png")
+
+	if _, err := os.Stat(renderedPath); err == nil {
+		return renderedPath, nil
+	}
+
+	img, err := Render(t, title)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(cacheDir, 0o755); err != nil {
+		return "", fmt.Errorf("unable to create template cache: %w", err)
+	}
+
+	// the image is written to a temporary file and renamed, so the cache never
+	// holds a partially written image
+	f, err := os.CreateTemp(cacheDir, t.Name+"-*.png.tmp")
+	if err != nil {
+		return "", fmt.Errorf("unable to write rendered template: %w", err)
+	}
+
+	err = png.Encode(f, img)
+	if err == nil {
+		err = f.Chmod(0o644)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return "", fmt.Errorf("unable to encode rendered template: %w", err)
+	}
+
+	if err := os.Rename(f.Name(), renderedPath); err != nil {
+		os.Remove(f.Name())
+		return "", fmt.Errorf("unable to write rendered template: %w", err)
+	}
+
+	return renderedPath, nil
+}
+
+// cacheKey hashes the template, the title and the content of the background
+// image, logo and font, so files edited in place are rendered again.
+func (t Template) cacheKey(title string) (string, error) {
+	spec, err := yaml.Marshal(t)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	h.Write(spec)
+	h.Write([]byte(title))
+
+	files := []string{t.BackgroundImage, t.Title.Font}
+	if t.Logo != nil {
+		files = append(files, t.Logo.Path)
+	}
+	for _, filePath := range files {
+		if filePath == "" {
+			continue
+		}
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return "", fmt.Errorf("unable to read template file: %w", err)
+		}
+		h.Write(content)
+	}
+
+	return hex.EncodeToString(h.Sum(nil))[:16], nil
+}
+
+// Render draws the template with the title.
+func Render(t Template, title string) (image.Image, error) {
+	width, height := t.Width, t.Height
+	if width == 0 {
+		width = defaultTemplateWidth
+	}
+	if height == 0 {
+		height = defaultTemplateHeight
+	}
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	background := color.Color(color.White)
+	if t.Background != "" {
+		var err error
+		background, err = t.color(t.Background)
+		if err != nil {
+			return nil, err
+		}
+	}
+	draw.Draw(img, img.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+
+	if t.BackgroundImage != "" {
+		bg, err := decodeFile(t.BackgroundImage)
+		if err != nil {
+			return nil, err
+		}
+		bg = crop(bg, float64(width)/float64(height), focalPoint{0.5, 0.5})
+		xdraw.CatmullRom.Scale(img, img.Bounds(), bg, bg.Bounds(), draw.Over, nil)
+	}
+
+	for _, rect := range t.Rects {
+		c, err := t.color(rect.Color)
+		if err != nil {
+			return nil, err
+		}
+		draw.Draw(img, rect.rect(), image.NewUniform(c), image.Point{}, draw.Over)
+	}
+
+	if t.Logo != nil {
+		logo, err := decodeFile(t.Logo.Path)
+		if err != nil {
+			return nil, err
+		}
+		lb := logo.Bounds()
+		logoWidth := t.Logo.Width
+		if logoWidth == 0 {
+			logoWidth = lb.Dx()
+		}
+		logoHeight := lb.Dy() * logoWidth / lb.Dx()
+		dst := image.Rect(t.Logo.X, t.Logo.Y, t.Logo.X+logoWidth, t.Logo.Y+logoHeight)
+		xdraw.CatmullRom.Scale(img, dst, logo, lb, draw.Over, nil)
+	}
+
+	if err := t.drawTitle(img, title); err != nil {
+		return nil, err
+	}
+
+	return img, nil
+}
+
+func (t Template) drawTitle(img *image.RGBA, title string) error {
+	text := t.Title
+	box := text.rect()
+	if box.Empty() {
+		box = img.Bounds().Inset(img.Bounds().Dx() / 10)
+	}
+
+	fontData := goregular.TTF
+	if text.Font != "" {
+		var err error
+		fontData, err = os.ReadFile(text.Font)
+		if err != nil {
+			return fmt.Errorf("unable to read font: %w", err)
+		}
+	}
+	f, err := opentype.Parse(fontData)
+	if err != nil {
+		return fmt.Errorf("unable to parse font %s: %w", text.Font, err)
+	}
+
+	size := text.Size
+	if size == 0 {
+		size = defaultFontSize
+	}
+	face, err := opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return fmt.Errorf("unable to create font face: %w", err)
+	}
+	defer face.Close()
+
+	textColor := color.Color(color.Black)
+	if text.Color != "" {
+		textColor, err = t.color(text.Color)
+		if err != nil {
+			return err
+		}
+	}
+
+	lineHeight := text.LineHeight
+	if lineHeight == 0 {
+		lineHeight = defaultLineHeight
+	}
+	lineAdvance := fixed.Int26_6(float64(face.Metrics().Height) * lineHeight)
+
+	d := &font.Drawer{Dst: img, Src: image.NewUniform(textColor), Face: face}
+	y := fixed.I(box.Min.Y) + face.Metrics().Ascent
+	for _, line := range wrapText(d, title, fixed.I(box.Dx())) {
+		if y+face.Metrics().Descent > fixed.I(box.Max.Y) {
+			break
+		}
+
+		x := fixed.I(box.Min.X)
+		switch text.Align {
+		case "center":
+			x += (fixed.I(box.Dx()) - d.MeasureString(line)) / 2
+		case "right":
+			x += fixed.I(box.Dx()) - d.MeasureString(line)
+		}
+
+		d.Dot = fixed.Point26_6{X: x, Y: y}
+		d.DrawString(line)
+		y += lineAdvance
+	}
+
+	return nil
+}
+
+// wrapText breaks the text into lines that fit into the width. Words longer
+// than the width get a line of their own.
+func wrapText(d *font.Drawer, text string, width fixed.Int26_6) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+		if line != "" && d.MeasureString(candidate) > width {
+			lines = append(lines, line)
+			candidate = word
+		}
+		line = candidate
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func (t Template) color(value string) (color.Color, error) {
+	if named, ok := t.Colors[value]; ok {
+		value = named
+	}
+
+	hex := strings.TrimPrefix(value, "#")
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	if !strings.HasPrefix(value, "#") || len(hex) != 8 {
+		return nil, fmt.Errorf("invalid color %s", value)
+	}
+
+	rgba, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid color %s", value)
+	}
+	return color.NRGBA{R: uint8(rgba >> 24), G: uint8(rgba >> 16), B: uint8(rgba >> 8), A: uint8(rgba)}, nil
+}
+
+func (b Box) rect() image.Rectangle {
+	return image.Rect(b.X, b.Y, b.X+b.Width, b.Y+b.Height)
+}
+
+func decodeFile(imgPath string) (image.Image, error) {
+	f, err := os.Open(imgPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open image: %w", err)
+	}
+	defer f.Close()
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode image %s: %w", imgPath, err)
+	}
+	return img, nil
+}
@@ -0,0 +1,119 @@
+package imageproc
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadTemplates(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "templates.yaml")
+	err := os.WriteFile(filePath, []byte(`templates:
+  - name: sale
+    width: 200
+    height: 300
+    background: brand
+    colors:
+      brand: "#ff0000"
+    rects:
+      - {x: 0, y: 250, width: 200, height: 50, color: "#00000080"}
+    title:
+      x: 10
+      y: 10
+      width: 180
+      height: 200
+      size: 24
+      align: center
+`), 0o644)
+	assert.NoError(t, err)
+
+	templates, err := ReadTemplates(filePath)
+	assert.NoError(t, err)
+
+	template, ok := FindTemplate(templates, "sale")
+	assert.True(t, ok)
+	assert.Equal(t, Box{X: 10, Y: 10, Width: 180, Height: 200}, template.Title.Box)
+	assert.Equal(t, Box{Y: 250, Width: 200, Height: 50}, template.Rects[0].Box)
+
+	img, err := Render(template, "Summer sale with a title that needs several lines")
+	assert.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 200, 300), img.Bounds())
+	assert.Equal(t, color.RGBA{R: 255, A: 255}, img.At(0, 0))
+	assert.Equal(t, color.RGBA{R: 127, A: 255}, img.At(0, 299))
+
+	textPixels := 0
+	for y := 0; y < 250; y++ {
+		for x := 0; x < 200; x++ {
+			if img.At(x, y) != (color.RGBA{R: 255, A: 255}) {
+				textPixels++
+				assert.True(t, image.Pt(x, y).In(image.Rect(10, 10, 190, 210)))
+			}
+		}
+	}
+	assert.True(t, textPixels > 0)
+
+	renderedPath, err := RenderToFile(template, "Summer sale", t.TempDir())
+	assert.NoError(t, err)
+	assert.Equal(t, ".png", filepath.Ext(renderedPath))
+
+	template.Background = "red"
+	_, err = Render(template, "Summer sale")
+	assert.EqualError(t, err, "invalid color red")
+}
+
+func TestIsTemplate(t *testing.T) {
+	name, ok := IsTemplate("template:sale")
+	assert.True(t, ok)
+	assert.Equal(t, "sale", name)
+
+	_, ok = IsTemplate("sale.png")
+	assert.False(t, ok)
+}
+
+func TestRenderToFileWithLogo(t *testing.T) {
+	dir := t.TempDir()
+	logoPath := filepath.Join(dir, "logo.png")
+	writeLogo := func(c color.Color) {
+		logo := image.NewRGBA(image.Rect(0, 0, 20, 10))
+		draw.Draw(logo, logo.Bounds(), image.NewUniform(c), image.Point{}, draw.Src)
+		f, err := os.Create(logoPath)
+		assert.NoError(t, err)
+		assert.NoError(t, png.Encode(f, logo))
+		assert.NoError(t, f.Close())
+	}
+	writeLogo(color.RGBA{B: 255, A: 255})
+
+	template := Template{Name: "logo", Width: 100, Height: 150, Logo: &TemplateLogo{Path: logoPath, Box: Box{X: 5, Y: 5}}}
+
+	// without width the logo keeps its own size
+	img, err := Render(template, "")
+	assert.NoError(t, err)
+	assert.Equal(t, color.RGBA{B: 255, A: 255}, img.At(5, 5))
+	assert.Equal(t, color.RGBA{B: 255, A: 255}, img.At(24, 14))
+	assert.Equal(t, color.RGBA{R: 255, G: 255, B: 255, A: 255}, img.At(25, 15))
+
+	cacheDir := filepath.Join(dir, "cache")
+	first, err := RenderToFile(template, "title", cacheDir)
+	assert.NoError(t, err)
+
+	// a logo edited in place is rendered again
+	writeLogo(color.RGBA{G: 255, A: 255})
+	second, err := RenderToFile(template, "title", cacheDir)
+	assert.NoError(t, err)
+	assert.NotEqual(t, first, second)
+
+	// only the rendered images are left in the cache
+	entries, err := os.ReadDir(cacheDir)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(entries))
+	for _, entry := range entries {
+		assert.True(t, strings.HasSuffix(entry.Name(), ".png"), entry.Name())
+	}
+}
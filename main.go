@@ -2,13 +2,19 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"os"
+	"strconv"
 	"time"
 
 	"pin-creator/accessToken"
+	"pin-creator/boards"
 	"pin-creator/config"
+	"pin-creator/imageproc"
 	"pin-creator/pinterest"
+	"pin-creator/pinterest/cassette"
+	"pin-creator/pinterest/pinteresttest"
 	"pin-creator/schedule"
 
 	"pin-creator/internal/logger"
@@ -16,19 +22,52 @@ import (
 
 var cfg *config.Config
 
+var (
+	recordPath = flag.String("record", "", "record the Pinterest API requests to this cassette file")
+	replayPath = flag.String("replay", "", "answer Pinterest API requests from this cassette file instead of calling the API")
+	useFakeApi = flag.Bool("fake-api", false, "run against an in-memory fake of the Pinterest API instead of Pinterest")
+)
+
+// fakeApi is the fake Pinterest API started for -fake-api.
+var fakeApi *pinteresttest.Server
+
 func main() {
 	baseCtx := context.Background()
 	ctx := logger.NewContext(baseCtx)
 
 	myLogger := logger.NewLogger(logger.LoggerConfig{UseJSON: false, LogLevel: 0})
 	ctx = logger.WithLogger(ctx, myLogger)
+
+	flag.Usage = usage
+	flag.Parse()
 	readConfig(ctx)
 
 	log := logger.FromContext(ctx)
+
+	if *useFakeApi {
+		fakeApi = pinteresttest.NewServer()
+		defer fakeApi.Close()
+		log.Info(fmt.Sprintf("Using fake Pinterest API at %s", fakeApi.URL))
+	}
+
+	if flag.NArg() > 1 {
+		err := runCommand(ctx, flag.Args()[1:])
+		if err != nil {
+			log.Error(err, fmt.Sprintf("error running %s", flag.Arg(1)))
+			os.Exit(1)
+		}
+		return
+	}
+
 	log.Info("Checking for pins to create in", cfg.ScheduleFilePath)
 
-	scheduleReader := schedule.NewScheduleReader(cfg.ScheduleFilePath)
-	nextPinData, err := scheduleReader.Next()
+	scheduleReader, _, err := newScheduleReader()
+	if err != nil {
+		log.Error(err, "error reading campaigns")
+		os.Exit(1)
+	}
+
+	nextPinData, err := scheduleReader.WithLogger(log).Next()
 	if err != nil {
 		log.Error(err, "error reading next schedule")
 		os.Exit(1)
@@ -46,32 +85,66 @@ func main() {
 	// }
 
 	start := time.Now()
-	err = createPin(ctx, nextPinData)
+	pin, err := createPin(ctx, nextPinData)
 	duration := time.Since(start)
 
 	if err != nil {
-		log.Error(err, "error creating pin")
-		os.Exit(1)
+		handleCreatePinError(ctx, scheduleReader, nextPinData, err)
+		return
 	}
 
 	log.Info(fmt.Sprintf("Pin creation took %s", duration.Truncate(time.Second)))
 
-	err = scheduleReader.SetCreated(nextPinData.Index)
+	if dryRun() {
+		log.Info(fmt.Sprintf("Dry run, not marking pin %s as created in the schedule", pin.ID))
+		return
+	}
+
+	err = scheduleReader.SetCreated(nextPinData.Index, pin.ID, nextPinData.Variant)
 	if err != nil {
 		log.Error(err, "error setting pin created to true")
 		os.Exit(1)
 	}
 }
 
+// handleCreatePinError defers rate limited pins to the next run and marks
+// pins that Pinterest rejected as invalid as failed, so they don't block the
+// schedule. All other errors end the run. A rejected access token is not
+// replaced here, as creating a new one needs a browser.
+func handleCreatePinError(ctx context.Context, scheduleReader schedule.ScheduleReaderInterface, pinData *schedule.NextPinData, err error) {
+	log := logger.FromContext(ctx)
+
+	switch {
+	case pinterest.IsUnauthorized(err):
+		log.Error(err, "Pinterest rejected the access token. Run the auth command to create a new token")
+		os.Exit(1)
+	case pinterest.IsRateLimited(err):
+		log.Info("Rate limited by Pinterest. The pin stays scheduled for the next run", "error", err.Error())
+	case pinterest.IsValidation(err):
+		if dryRun() {
+			log.Error(err, "Pinterest rejected the pin")
+			os.Exit(1)
+		}
+		log.Error(err, "Pinterest rejected the pin. Marking it as failed")
+		if err := scheduleReader.SetFailed(pinData.Index); err != nil {
+			log.Error(err, "error marking pin as failed")
+		}
+		os.Exit(1)
+	default:
+		log.Error(err, "error creating pin")
+		os.Exit(1)
+	}
+}
+
 func readConfig(ctx context.Context) {
 	log := logger.FromContext(ctx)
-	args := os.Args
-	if len(args) != 2 {
+	if flag.NArg() < 1 {
 		log.Error(nil, "config.yaml file not provided")
+		flag.Usage()
 		os.Exit(1)
 	}
 
-	configFilePath := args[1]
+	configFilePath := flag.Arg(0)
 
 	cr := config.NewReader(configFilePath)
 	c, err := cr.Read()
@@ -80,20 +153,87 @@ func readConfig(ctx context.Context) {
 		os.Exit(1)
 	}
 	cfg = c
+
+	if _, err := pinterest.ApiUrl(cfg.Environment, cfg.ApiBaseUrl); err != nil {
+		log.Error(err, fmt.Sprintf("error reading %s", configFilePath))
+		os.Exit(1)
+	}
+}
+
+// dryRun reports whether the API answers don't come from Pinterest, so the run
+// must not change the schedule, the board cache or the access token.
+func dryRun() bool {
+	return *replayPath != "" || fakeApi != nil
+}
+
+func environment() string {
+	if cfg.Environment == "" {
+		return pinterest.EnvironmentSandbox
+	}
+	return cfg.Environment
+}
+
+func apiUrl() string {
+	if fakeApi != nil {
+		return fakeApi.URL
+	}
+	// validated by readConfig
+	url, _ := pinterest.ApiUrl(cfg.Environment, cfg.ApiBaseUrl)
+	return url
+}
+
+// accessTokenPath returns the token file of the environment, so sandbox and
+// production tokens are never mixed up. Token files from before environments
+// existed hold sandbox tokens and are moved to the sandbox token file.
+func accessTokenPath(ctx context.Context) string {
+	log := logger.FromContext(ctx)
+	path := cfg.AccessTokenPath + "." + environment()
+
+	if environment() == pinterest.EnvironmentSandbox {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			if _, err := os.Stat(cfg.AccessTokenPath); err == nil {
+				log.Info(fmt.Sprintf("Moving sandbox access token to %s", path))
+				if err := os.Rename(cfg.AccessTokenPath, path); err != nil {
+					log.Error(err, "error moving access token file")
+				}
+			}
+		}
+	}
+
+	return path
+}
+
+func newScheduleReader() (*schedule.ScheduleReader, []schedule.Campaign, error) {
+	scheduleReader := schedule.NewScheduleReader(cfg.ScheduleFilePath).
+		WithVariantStrategy(cfg.VariantStrategy, cfg.VariantSeed)
+	if cfg.CampaignsFilePath == "" {
+		return scheduleReader, nil, nil
+	}
+
+	campaigns, err := schedule.ReadCampaigns(cfg.CampaignsFilePath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return scheduleReader.WithCampaigns(campaigns), campaigns, nil
 }
 
 func getToken(ctx context.Context) string {
 	log := logger.FromContext(ctx)
-	tokenFileHandler := accessToken.NewAccessTokenFileHandler(cfg.AccessTokenPath)
+	if fakeApi != nil {
+		return fakeApi.Token()
+	}
+
+	tokenFileHandler := accessToken.NewAccessTokenFileHandler(accessTokenPath(ctx))
 
-	log.Info("Reading access token from file")
+	log.Info(fmt.Sprintf("Reading %s access token from file", environment()))
 	token, err := tokenFileHandler.Read()
 	if err == nil {
 		return token
 	} else {
 		log.Info("No access token file found. Creating new token")
 
-		tokenCreator := accessToken.NewAccessAccessTokenCreator(cfg.BrowserPath, cfg.RedirectPort)
+		tokenCreator := accessToken.NewAccessAccessTokenCreator(cfg.BrowserPath, cfg.RedirectPort).WithApiUri(apiUrl())
 		appId := os.Getenv("APP_ID")
 		appSecret := os.Getenv("APP_SECRET")
 
@@ -114,45 +254,337 @@ func getToken(ctx context.Context) string {
 }
 
 func getClient(ctx context.Context) pinterest.ClientInterface {
-	token := getToken(ctx)
-	return pinterest.NewClient(token)
+	log := logger.FromContext(ctx)
+	var options []pinterest.ClientOption
+
+	// Replaying needs no access token, so a cassette recorded in production
+	// can be debugged without credentials.
+	token := ""
+	if *replayPath != "" {
+		log.Info(fmt.Sprintf("Replaying Pinterest API requests from %s", *replayPath))
+		replayer, err := cassette.NewReplayer(*replayPath)
+		if err != nil {
+			log.Error(err, "error reading cassette")
+			os.Exit(1)
+		}
+		options = append(options, pinterest.WithTransport(replayer))
+	} else {
+		token = getToken(ctx)
+	}
+
+	if *recordPath != "" {
+		log.Info(fmt.Sprintf("Recording Pinterest API requests to %s", *recordPath))
+		options = append(options, pinterest.WithMiddleware(cassette.NewRecorder(*recordPath).Middleware()))
+	}
+
+	return pinterest.NewClient(token, append([]pinterest.ClientOption{
+		pinterest.WithApiUrl(apiUrl()),
+		pinterest.WithLogger(logger.FromContext(ctx)),
+		pinterest.WithRateLimitPolicy(pinterest.RateLimitPolicy{
+			PerMinute: cfg.RateLimit.PerMinute,
+			FailFast:  cfg.RateLimit.FailFast,
+			MaxWait:   cfg.RateLimit.MaxWait,
+		}),
+		pinterest.WithRetryPolicy(pinterest.RetryPolicy{
+			Disabled:        cfg.Retry.Disabled,
+			MaxRetries:      cfg.Retry.MaxRetries,
+			InitialInterval: cfg.Retry.InitialInterval,
+			MaxInterval:     cfg.Retry.MaxInterval,
+		}),
+	}, options...)...)
 }
 
-func createPin(ctx context.Context, scheduledPinData *schedule.NextPinData) error {
+// logRateLimits reports the rate limits Pinterest returned with the requests of
+// the client.
+func logRateLimits(ctx context.Context, client pinterest.ClientInterface) {
+	log := logger.FromContext(ctx)
+	for _, limit := range client.RateLimits() {
+		log.Info(fmt.Sprintf("Rate limit %s: %d of %d requests left, resets at %s", limit.Category, limit.Remaining, limit.Limit, limit.Reset.Format(time.RFC1123)))
+	}
+}
+
+// getBoardCache returns the board cache, or nil if there is none. Dry runs use
+// no cache, as the board ids they see aren't the ones of the account.
+func getBoardCache(ctx context.Context) *pinterest.BoardCache {
+	log := logger.FromContext(ctx)
+	if cfg.BoardCachePath == "" || dryRun() {
+		return nil
+	}
+
+	cache, err := pinterest.NewBoardCache(cfg.BoardCachePath, cfg.BoardCacheTTL)
+	if err != nil {
+		log.Error(err, "error reading board cache. Continuing without cache")
+		return nil
+	}
+	return cache
+}
+
+// boardTemplateFor returns the settings used if the board has to be created.
+// Boards described in the boards file are created as described there, other
+// boards use the board overrides and defaults of the config.
+func boardTemplateFor(boardName string) (pinterest.BoardTemplate, error) {
+	template := pinterest.DefaultBoardTemplate(boardName)
+	template.NoCreate = cfg.DisableBoardCreation
+
+	if cfg.BoardsFilePath != "" {
+		specs, err := boards.ReadFile(cfg.BoardsFilePath)
+		if err != nil {
+			return pinterest.BoardTemplate{}, err
+		}
+
+		if spec, ok := boards.Find(specs, boardName); ok {
+			specTemplate := spec.Template()
+			specTemplate.NoCreate = cfg.DisableBoardCreation
+			return specTemplate, nil
+		}
+	}
+
+	settings := cfg.BoardSettingsFor(boardName)
+	if settings.Description != "" {
+		template.Description = settings.Description
+	}
+	if settings.Privacy != "" {
+		if !pinterest.ValidPrivacy(settings.Privacy) {
+			return pinterest.BoardTemplate{}, fmt.Errorf("invalid privacy %s configured for board %s", settings.Privacy, boardName)
+		}
+		template.Privacy = settings.Privacy
+	}
+	template.Sections = settings.Sections
+
+	return template, nil
+}
+
+func resolveBoard(ctx context.Context, client pinterest.ClientInterface, cache *pinterest.BoardCache, boardName string) (string, bool, error) {
 	log := logger.FromContext(ctx)
-	client := getClient(ctx)
 
 	boardCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
 	defer cancel()
-	boardId, err := pinterest.CreateOrFindBoard(boardCtx, client, log, scheduledPinData.BoardName)
+	template, err := boardTemplateFor(boardName)
+	if err != nil {
+		return "", false, err
+	}
+
+	boardId, cached, err := pinterest.ResolveBoard(boardCtx, client, cache, log, template)
 	if err != nil {
 		if err == context.DeadlineExceeded {
 			log.Error(err, "Timeout occurred while creating or finding board")
-			return fmt.Errorf("timeout occurred while creating or finding board: %w", err)
+			return "", false, fmt.Errorf("timeout occurred while creating or finding board: %w", err)
 		}
-		return fmt.Errorf("failed to create or find board: %w", err)
+		return "", false, fmt.Errorf("failed to create or find board: %w", err)
+	}
+	return boardId, cached, nil
+}
+
+// resolveBoardAndSection returns the ids of the board and, if the row names one,
+// the section of the row. The returned flag reports whether any id came from
+// the cache.
+func resolveBoardAndSection(ctx context.Context, client pinterest.ClientInterface, cache *pinterest.BoardCache, scheduledPinData *schedule.NextPinData) (string, string, bool, error) {
+	log := logger.FromContext(ctx)
+
+	boardId, boardCached, err := resolveBoard(ctx, client, cache, scheduledPinData.BoardName)
+	if err != nil {
+		return "", "", false, err
+	}
+
+	if scheduledPinData.SectionName == "" {
+		return boardId, "", boardCached, nil
+	}
+
+	sectionCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+	sectionId, sectionCached, err := pinterest.ResolveBoardSection(sectionCtx, client, cache, log, scheduledPinData.BoardName, boardId, scheduledPinData.SectionName)
+	if err != nil {
+		return "", "", false, fmt.Errorf("failed to create or find board section: %w", err)
+	}
+
+	return boardId, sectionId, boardCached || sectionCached, nil
+}
+
+func createPin(ctx context.Context, scheduledPinData *schedule.NextPinData) (*pinterest.Pin, error) {
+	log := logger.FromContext(ctx)
+	client := getClient(ctx)
+	cache := getBoardCache(ctx)
+
+	boardId, sectionId, cached, err := resolveBoardAndSection(ctx, client, cache, scheduledPinData)
+	if err != nil {
+		return nil, err
 	}
 
 	pinData := pinterest.PinData{
-		BoardId:     boardId,
-		ImgPath:     scheduledPinData.ImagePath,
-		Link:        scheduledPinData.Link,
-		Title:       scheduledPinData.Title,
-		Description: scheduledPinData.Description,
-		AltText:     scheduledPinData.Description,
+		BoardId:          boardId,
+		BoardSectionId:   sectionId,
+		ImgPath:          scheduledPinData.ImagePath,
+		Link:             scheduledPinData.Link,
+		Title:            scheduledPinData.Title,
+		Description:      scheduledPinData.Description,
+		AltText:          scheduledPinData.Description,
+		Variant:          scheduledPinData.Variant,
+		ConvertTo:        cfg.ImageConversion,
+		DownloadImageUrl: cfg.DownloadImageUrls,
+	}
+
+	for _, item := range scheduledPinData.Items {
+		pinData.Items = append(pinData.Items, pinterest.PinItem{
+			ImgPath:     item.ImagePath,
+			Title:       item.Title,
+			Description: item.Description,
+			Link:        item.Link,
+		})
+	}
+
+	if keyFrameTime, err := strconv.Atoi(scheduledPinData.Cover); err == nil {
+		pinData.CoverKeyFrameTime = keyFrameTime
+	} else {
+		pinData.CoverImagePath = scheduledPinData.Cover
+	}
+
+	if len(scheduledPinData.Variants) > 1 {
+		for _, variant := range scheduledPinData.Variants {
+			pinData.Variants = append(pinData.Variants, pinterest.PinVariant{
+				Title:       variant.Title,
+				Description: variant.Description,
+			})
+		}
+	}
+
+	if err := renderTemplate(&pinData); err != nil {
+		return nil, err
+	}
+	if err := processImages(&pinData); err != nil {
+		return nil, err
+	}
+
+	pin, err := doCreatePin(ctx, client, pinData)
+	if err != nil && cached && pinterest.IsNotFound(err) {
+		log.Info(fmt.Sprintf("Cached id of board '%s' is stale. Resolving board again", scheduledPinData.BoardName))
+		if err := cache.Invalidate(scheduledPinData.BoardName); err != nil {
+			log.Error(err, "error invalidating board cache")
+		}
+		if scheduledPinData.SectionName != "" {
+			if err := cache.Invalidate(pinterest.SectionCacheKey(scheduledPinData.BoardName, scheduledPinData.SectionName)); err != nil {
+				log.Error(err, "error invalidating board cache")
+			}
+		}
+
+		pinData.BoardId, pinData.BoardSectionId, _, err = resolveBoardAndSection(ctx, client, cache, scheduledPinData)
+		if err != nil {
+			return nil, err
+		}
+		pin, err = doCreatePin(ctx, client, pinData)
+	}
+	logRateLimits(ctx, client)
+	if err != nil {
+		return nil, err
 	}
 
-	pinCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	if scheduledPinData.SectionName != "" {
+		log.Info(fmt.Sprintf("Created Pin '%s' in section '%s' of board '%s'", pin.Title, scheduledPinData.SectionName, scheduledPinData.BoardName))
+		return pin, nil
+	}
+
+	log.Info(fmt.Sprintf("Created Pin '%s' in board '%s'", pin.Title, scheduledPinData.BoardName))
+	return pin, nil
+}
+
+// renderTemplate replaces an image of the form template:<name> with the
+// template rendered with the title of the pin.
+func renderTemplate(pinData *pinterest.PinData) error {
+	name, ok := imageproc.IsTemplate(pinData.ImgPath)
+	if !ok {
+		return nil
+	}
+	if cfg.TemplatesFilePath == "" {
+		return fmt.Errorf("image %s needs templates_file_path in the config", pinData.ImgPath)
+	}
+
+	templates, err := imageproc.ReadTemplates(cfg.TemplatesFilePath)
+	if err != nil {
+		return err
+	}
+	template, ok := imageproc.FindTemplate(templates, name)
+	if !ok {
+		return fmt.Errorf("template %s not found in %s", name, cfg.TemplatesFilePath)
+	}
+
+	title := pinData.Title
+	if len(pinData.Variants) > 0 && pinData.Variant < len(pinData.Variants) {
+		title = pinData.Variants[pinData.Variant].Title
+	}
+
+	cacheDir := cfg.TemplateCacheDir
+	if cacheDir == "" {
+		cacheDir = ".template_cache"
+	}
+	pinData.ImgPath, err = imageproc.RenderToFile(template, title, cacheDir)
+	if err != nil {
+		return fmt.Errorf("unable to render template %s: %w", name, err)
+	}
+	return nil
+}
+
+// processImages replaces the local images of the pin with their processed
+// version if image processing is configured.
+func processImages(pinData *pinterest.PinData) error {
+	if cfg.ImageProcessing == nil {
+		return nil
+	}
+
+	cacheDir := cfg.ImageProcessing.CacheDir
+	if cacheDir == "" {
+		cacheDir = ".image_cache"
+	}
+	processor, err := imageproc.NewProcessor(imageproc.Options{
+		AspectRatio: cfg.ImageProcessing.AspectRatio,
+		FocalPoint:  cfg.ImageProcessing.FocalPoint,
+		MaxWidth:    cfg.ImageProcessing.MaxWidth,
+		Format:      cfg.ImageProcessing.Format,
+		Quality:     cfg.ImageProcessing.Quality,
+		MaxBytes:    cfg.ImageProcessing.MaxBytes,
+		CacheDir:    cacheDir,
+	})
+	if err != nil {
+		return fmt.Errorf("invalid image processing config: %w", err)
+	}
+
+	process := func(imgPath string) (string, error) {
+		if pinterest.IsImageUrl(imgPath) || pinterest.IsVideo(imgPath) {
+			return imgPath, nil
+		}
+		return processor.Process(imgPath)
+	}
+
+	if len(pinData.Items) > 0 {
+		for i := range pinData.Items {
+			pinData.Items[i].ImgPath, err = process(pinData.Items[i].ImgPath)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	pinData.ImgPath, err = process(pinData.ImgPath)
+	return err
+}
+
+func doCreatePin(ctx context.Context, client pinterest.ClientInterface, pinData pinterest.PinData) (*pinterest.Pin, error) {
+	log := logger.FromContext(ctx)
+
+	// videos are uploaded and processed before the pin is created
+	timeout := 30 * time.Second
+	if pinterest.IsVideo(pinData.ImgPath) {
+		timeout = 15 * time.Minute
+	}
+
+	pinCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
-	err = client.CreatePin(pinCtx, pinData)
+	pin, err := client.CreatePin(pinCtx, pinData)
 	if err != nil {
 		if err == context.DeadlineExceeded {
 			log.Error(err, "Timeout occurred while creating pin")
-			return fmt.Errorf("timeout occurred while creating pin: %w", err)
+			return nil, fmt.Errorf("timeout occurred while creating pin: %w", err)
 		}
-		return fmt.Errorf("failed to create pin: %w", err)
+		return nil, fmt.Errorf("failed to create pin: %w", err)
 	}
-
-	log.Info(fmt.Sprintf("Created Pin '%s' in board '%s'", pinData.Title, scheduledPinData.BoardName))
-	return nil
+	return pin, nil
 }
@@ -3,15 +3,27 @@ package pinterest
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/go-logr/logr"
 )
 
 const (
-	defaultWaitTime = 2 * time.Second
+	defaultBoardDescription = "Created by pin-creator"
+	defaultBoardPrivacy     = PrivacyPublic
 )
 
+// DefaultBoardTemplate returns the settings of a board that is created for a
+// schedule row when nothing else is configured for it.
+func DefaultBoardTemplate(boardName string) BoardTemplate {
+	return BoardTemplate{
+		BoardData: BoardData{
+			Name:        boardName,
+			Description: defaultBoardDescription,
+			Privacy:     defaultBoardPrivacy,
+		},
+	}
+}
+
 type ErrBoardNotFound struct {
 	BoardName string
 }
@@ -20,8 +32,8 @@ func (e ErrBoardNotFound) Error() string {
 	return fmt.Sprintf("board %s not found", e.BoardName)
 }
 
-func findOrCreateBoard(ctx context.Context, client ClientInterface, log logr.Logger, boardName string) (string, error) {
-	boardID, err := findBoard(ctx, client, log, boardName)
+func findOrCreateBoard(ctx context.Context, client ClientInterface, log logr.Logger, template BoardTemplate) (string, error) {
+	boardID, err := findBoard(ctx, client, log, template.Name)
 	if err == nil {
 		return boardID, nil
 	}
@@ -30,18 +42,25 @@ func findOrCreateBoard(ctx context.Context, client ClientInterface, log logr.Log
 		return "", fmt.Errorf("error finding board: %w", err)
 	}
 
-	log.V(1).Info("Board not found. Creating new board.", "boardName", boardName)
-	err = client.CreateBoard(ctx, BoardData{
-		Name:        boardName,
-		Description: "Created by pin-creator",
-		Privacy:     "PUBLIC",
-	})
+	if template.NoCreate {
+		log.V(1).Info("Board not found and board creation is disabled.", "boardName", template.Name)
+		return "", err
+	}
+
+	log.V(1).Info("Board not found. Creating new board.", "boardName", template.Name)
+	board, err := client.CreateBoard(ctx, template.BoardData)
 	if err != nil {
 		return "", fmt.Errorf("error creating board: %w", err)
 	}
 
-	log.V(2).Info("Board creation request sent successfully")
-	time.Sleep(defaultWaitTime)
+	log.V(2).Info("Board created successfully", "boardName", board.Name, "boardId", board.Id)
+
+	for _, sectionName := range template.Sections {
+		_, err := client.CreateBoardSection(ctx, board.Id, sectionName)
+		if err != nil {
+			log.Error(err, "error creating initial board section", "boardName", board.Name, "sectionName", sectionName)
+		}
+	}
 
-	return findBoard(ctx, client, log, boardName)
+	return board.Id, nil
 }
@@ -0,0 +1,174 @@
+package pinterest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	DefaultBoardCacheTTL = 24 * time.Hour
+)
+
+type boardCacheEntry struct {
+	Id       string    `json:"id"`
+	CachedAt time.Time `json:"cached_at"`
+}
+
+// BoardCache is a file backed cache of board ids by board name. Entries expire
+// after the TTL and must be invalidated when Pinterest no longer knows the id.
+type BoardCache struct {
+	filePath string
+	ttl      time.Duration
+	mu       sync.Mutex
+	entries  map[string]boardCacheEntry
+	now      func() time.Time
+}
+
+func NewBoardCache(filePath string, ttl time.Duration) (*BoardCache, error) {
+	if ttl <= 0 {
+		ttl = DefaultBoardCacheTTL
+	}
+
+	c := &BoardCache{
+		filePath: filePath,
+		ttl:      ttl,
+		entries:  map[string]boardCacheEntry{},
+		now:      time.Now,
+	}
+
+	bytes, err := os.ReadFile(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read board cache: %w", err)
+	}
+
+	if err := json.Unmarshal(bytes, &c.entries); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal board cache: %w", err)
+	}
+
+	return c, nil
+}
+
+// Get returns the cached id of the board if it hasn't expired.
+func (c *BoardCache) Get(boardName string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[boardName]
+	if !ok || c.now().Sub(entry.CachedAt) > c.ttl {
+		return "", false
+	}
+	return entry.Id, true
+}
+
+func (c *BoardCache) Set(boardName string, boardId string) error {
+	c.mu.Lock()
+	c.entries[boardName] = boardCacheEntry{Id: boardId, CachedAt: c.now()}
+	c.mu.Unlock()
+
+	return c.save()
+}
+
+func (c *BoardCache) Invalidate(boardName string) error {
+	c.mu.Lock()
+	delete(c.entries, boardName)
+	c.mu.Unlock()
+
+	return c.save()
+}
+
+// RenameSection moves the cached id of a renamed section of the board to the
+// new name of the section.
+func (c *BoardCache) RenameSection(boardName string, oldName string, newName string, sectionId string) error {
+	c.mu.Lock()
+	delete(c.entries, SectionCacheKey(boardName, oldName))
+	c.entries[SectionCacheKey(boardName, newName)] = boardCacheEntry{Id: sectionId, CachedAt: c.now()}
+	c.mu.Unlock()
+
+	return c.save()
+}
+
+// InvalidateSection removes the cached id of a section of the board.
+func (c *BoardCache) InvalidateSection(boardName string, sectionName string) error {
+	return c.Invalidate(SectionCacheKey(boardName, sectionName))
+}
+
+func (c *BoardCache) save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	bytes, err := json.MarshalIndent(c.entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal board cache: %w", err)
+	}
+
+	if err := os.WriteFile(c.filePath, bytes, 0o600); err != nil {
+		return fmt.Errorf("unable to write board cache: %w", err)
+	}
+	return nil
+}
+
+// ResolveBoard returns the id of the board from the cache and only falls back to
+// CreateOrFindBoard on a cache miss. The returned flag reports whether the id
+// came from the cache. A nil cache always resolves through the API.
+func ResolveBoard(ctx context.Context, client ClientInterface, cache *BoardCache, log logr.Logger, template BoardTemplate) (string, bool, error) {
+	boardName := template.Name
+	if cache != nil {
+		if boardId, ok := cache.Get(boardName); ok {
+			log.V(2).Info("board found in cache", "boardName", boardName, "boardId", boardId)
+			return boardId, true, nil
+		}
+	}
+
+	boardId, err := CreateOrFindBoard(ctx, client, log, template)
+	if err != nil {
+		return "", false, err
+	}
+
+	if cache != nil {
+		if err := cache.Set(boardName, boardId); err != nil {
+			log.Error(err, "error caching board id", "boardName", boardName)
+		}
+	}
+
+	return boardId, false, nil
+}
+
+// ResolveBoardSection returns the id of the section of the board from the cache
+// and only falls back to CreateOrFindBoardSection on a cache miss.
+func ResolveBoardSection(ctx context.Context, client ClientInterface, cache *BoardCache, log logr.Logger, boardName string, boardId string, sectionName string) (string, bool, error) {
+	key := SectionCacheKey(boardName, sectionName)
+	if cache != nil {
+		if sectionId, ok := cache.Get(key); ok {
+			log.V(2).Info("board section found in cache", "sectionName", sectionName, "sectionId", sectionId)
+			return sectionId, true, nil
+		}
+	}
+
+	sectionId, err := CreateOrFindBoardSection(ctx, client, log, boardId, sectionName)
+	if err != nil {
+		return "", false, err
+	}
+
+	if cache != nil {
+		if err := cache.Set(key, sectionId); err != nil {
+			log.Error(err, "error caching board section id", "sectionName", sectionName)
+		}
+	}
+
+	return sectionId, false, nil
+}
+
+// SectionCacheKey is the key under which the id of a board section is cached.
+func SectionCacheKey(boardName string, sectionName string) string {
+	return boardName + "::" + sectionName
+}
@@ -0,0 +1,40 @@
+package pinterest
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoardCache(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "boards.json")
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+
+	cache, err := NewBoardCache(filePath, time.Hour)
+	assert.NoError(t, err)
+	cache.now = func() time.Time { return now }
+
+	_, ok := cache.Get("board")
+	assert.False(t, ok)
+
+	assert.NoError(t, cache.Set("board", "1234"))
+	assert.NoError(t, cache.Set("other", "5678"))
+	assert.NoError(t, cache.Invalidate("other"))
+
+	reloaded, err := NewBoardCache(filePath, time.Hour)
+	assert.NoError(t, err)
+	reloaded.now = func() time.Time { return now.Add(30 * time.Minute) }
+
+	boardId, ok := reloaded.Get("board")
+	assert.True(t, ok)
+	assert.Equal(t, "1234", boardId)
+
+	_, ok = reloaded.Get("other")
+	assert.False(t, ok)
+
+	reloaded.now = func() time.Time { return now.Add(2 * time.Hour) }
+	_, ok = reloaded.Get("board")
+	assert.False(t, ok)
+}
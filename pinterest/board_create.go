@@ -2,31 +2,51 @@ package pinterest
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
-	"github.com/cenkalti/backoff/v4"
 	"github.com/go-logr/logr"
 )
 
-const (
-	defaultRetryTimeout = 60 * time.Second
-	maxRetries          = 5
-)
+// defaultResolveTimeout limits finding or creating a board or section,
+// including the retries of its requests.
+const defaultResolveTimeout = 60 * time.Second
 
 type ownerRequestBody struct {
 	Username string `json:"username"`
 }
 
 type boardRequestBody struct {
-	Id          string           `json:"id"`
-	Name        string           `json:"name"`
-	Description string           `json:"description"`
-	Owner       ownerRequestBody `json:"owner"`
-	Privacy     string           `json:"privacy"`
+	Id            string           `json:"id"`
+	Name          string           `json:"name"`
+	Description   string           `json:"description"`
+	Owner         ownerRequestBody `json:"owner"`
+	Privacy       string           `json:"privacy"`
+	PinCount      int              `json:"pin_count"`
+	FollowerCount int              `json:"follower_count"`
+	CreatedAt     *CustomTime      `json:"created_at"`
 }
 
-func (c *Client) CreateBoard(ctx context.Context, boardData BoardData) error {
+func (b boardRequestBody) toBoardInfo() BoardInfo {
+	boardInfo := BoardInfo{
+		Id:            b.Id,
+		Name:          b.Name,
+		Description:   b.Description,
+		Privacy:       b.Privacy,
+		PinCount:      b.PinCount,
+		FollowerCount: b.FollowerCount,
+		Owner:         BoardOwner{Username: b.Owner.Username},
+	}
+	if b.CreatedAt != nil {
+		boardInfo.CreatedAt = b.CreatedAt.Time
+	}
+	return boardInfo
+}
+
+// CreateBoard creates the board and returns it as answered by Pinterest, so its
+// id can be used right away.
+func (c *Client) CreateBoard(ctx context.Context, boardData BoardData) (*BoardInfo, error) {
 	createBoardRequestBody := BoardData{
 		Name:        boardData.Name,
 		Description: boardData.Description,
@@ -36,44 +56,47 @@ func (c *Client) CreateBoard(ctx context.Context, boardData BoardData) error {
 	return c.doCreateBoard(ctx, createBoardRequestBody)
 }
 
-func (c *Client) doCreateBoard(ctx context.Context, body BoardData) error {
+func (c *Client) doCreateBoard(ctx context.Context, body BoardData) (*BoardInfo, error) {
 	url := fmt.Sprintf("%s%s", c.baseUrl, "boards")
 
 	req, err := c.createRequest("POST", url, body)
 	if err != nil {
-		return fmt.Errorf("error creating request: %v", err)
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	responseBody, err := c.executeRequest(ctx, req, 201)
+	if err != nil {
+		return nil, fmt.Errorf("error executing request: %w", err)
 	}
 
-	_, err = c.executeRequest(ctx, req, 201)
+	board, err := parseBoard(responseBody)
 	if err != nil {
-		return fmt.Errorf("error executing request: %v", err)
+		return nil, err
+	}
+	if board.Id == "" {
+		return nil, fmt.Errorf("response of board creation has no board id")
 	}
 
-	return nil
+	return board, nil
 }
 
-func CreateOrFindBoard(ctx context.Context, client ClientInterface, log logr.Logger, boardName string) (string, error) {
-	ctx, cancel := context.WithTimeout(ctx, defaultRetryTimeout)
+// CreateOrFindBoard returns the id of the board named template.Name and creates
+// the board from the template if it doesn't exist. If the template forbids
+// creation, a missing board fails right away with ErrBoardNotFound. Failed
+// requests are retried by the client, so the lookup itself isn't repeated.
+func CreateOrFindBoard(ctx context.Context, client ClientInterface, log logr.Logger, template BoardTemplate) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultResolveTimeout)
 	defer cancel()
 
-	var boardID string
-	operation := func() error {
-		var err error
-		boardID, err = findOrCreateBoard(ctx, client, log, boardName)
-		if err != nil {
-			log.Error(err, "Failed to find or create board", "boardName", boardName)
-		}
-		return err
-	}
-
-	backOff := backoff.WithMaxRetries(backoff.NewExponentialBackOff(), maxRetries)
-
-	err := backoff.Retry(operation, backoff.WithContext(backOff, ctx))
+	boardID, err := findOrCreateBoard(ctx, client, log, template)
 	if err != nil {
-		if err == context.DeadlineExceeded {
+		if errors.Is(err, context.DeadlineExceeded) {
 			return "", fmt.Errorf("timeout occurred while trying to create or find board: %w", err)
 		}
-		return "", fmt.Errorf("failed to create or find board after retries: %w", err)
+		if _, ok := err.(ErrBoardNotFound); ok {
+			return "", fmt.Errorf("board creation is disabled: %w", err)
+		}
+		return "", fmt.Errorf("failed to create or find board: %w", err)
 	}
 
 	return boardID, nil
@@ -0,0 +1,63 @@
+package pinterest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindOrCreateBoardUsesCreatedBoard(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"items":[],"bookmark":null}`)
+		case "POST":
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id":"42","name":"new board","privacy":"PUBLIC","created_at":"2024-06-30T04:54:04"}`)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("token")
+	client.baseUrl = server.URL + "/"
+
+	boardId, err := findOrCreateBoard(context.Background(), client, logr.Discard(), DefaultBoardTemplate("new board"))
+	assert.NoError(t, err)
+	assert.Equal(t, "42", boardId)
+	assert.Equal(t, 2, requests)
+
+	board, err := client.CreateBoard(context.Background(), BoardData{Name: "new board"})
+	assert.NoError(t, err)
+	assert.Equal(t, &BoardInfo{
+		Id:        "42",
+		Name:      "new board",
+		Privacy:   "PUBLIC",
+		CreatedAt: time.Date(2024, 6, 30, 4, 54, 4, 0, time.UTC),
+	}, board)
+}
+
+func TestFindOrCreateBoardWithoutCreation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		fmt.Fprint(w, `{"items":[],"bookmark":null}`)
+	}))
+	defer server.Close()
+
+	client := NewClient("token")
+	client.baseUrl = server.URL + "/"
+
+	template := DefaultBoardTemplate("new board")
+	template.NoCreate = true
+	_, err := findOrCreateBoard(context.Background(), client, logr.Discard(), template)
+	assert.Equal(t, ErrBoardNotFound{BoardName: "new board"}, err)
+}
@@ -4,32 +4,64 @@ import (
 	"context"
 	"fmt"
 	"regexp"
-
-	"pin-creator/internal/logger"
+	"strings"
 )
 
+// DeleteBoardsError lists the boards DeleteBoards failed to delete.
+type DeleteBoardsError struct {
+	Errors []error
+}
+
+func (e *DeleteBoardsError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		msgs = append(msgs, err.Error())
+	}
+	return fmt.Sprintf("unable to delete %d boards: %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+func (e *DeleteBoardsError) Unwrap() []error {
+	return e.Errors
+}
+
+// DeleteBoards deletes all boards whose name matches the regex. Boards that
+// fail to delete don't stop the others, their errors are returned together as
+// DeleteBoardsError.
 func (client *Client) DeleteBoards(ctx context.Context, regex string) error {
-	log := logger.FromContext(ctx)
+	log := client.log(ctx)
+	r, err := regexp.Compile(regex)
+	if err != nil {
+		return fmt.Errorf("invalid board regex: %w", err)
+	}
+
 	boards, err := client.ListBoards(ctx)
 	if err != nil {
 		return err
 	}
 
-	r := regexp.MustCompile(regex)
+	var errs []error
 	for _, board := range boards {
 		if r.MatchString(board.Name) {
 			err := client.doDeleteBoard(ctx, board.Id)
 			if err != nil {
 				log.Error(err, fmt.Sprintf("error deleting board %s", board.Name))
+				errs = append(errs, fmt.Errorf("board %s: %w", board.Name, err))
 			} else {
 				log.Info(fmt.Sprintf("Deleted board: %s", board.Name))
 			}
 		}
 	}
 
+	if len(errs) > 0 {
+		return &DeleteBoardsError{Errors: errs}
+	}
 	return nil
 }
 
+func (client *Client) DeleteBoard(ctx context.Context, boardId string) error {
+	return client.doDeleteBoard(ctx, boardId)
+}
+
 func (client *Client) doDeleteBoard(ctx context.Context, boardId string) error {
 	url := fmt.Sprintf("%s%s/%s", client.baseUrl, "boards", boardId)
 
@@ -40,7 +72,7 @@ func (client *Client) doDeleteBoard(ctx context.Context, boardId string) error {
 
 	_, err = client.executeRequest(ctx, req, 204)
 	if err != nil {
-		return fmt.Errorf("error executing request: %v", err)
+		return fmt.Errorf("error executing request: %w", err)
 	}
 
 	return nil
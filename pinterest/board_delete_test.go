@@ -0,0 +1,43 @@
+package pinterest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteBoards(t *testing.T) {
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET":
+			fmt.Fprint(w, `{"items":[{"id":"1","name":"test1"},{"id":"2","name":"test2"},{"id":"3","name":"keep"}]}`)
+		case r.URL.Path == "/boards/2":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"code":40,"message":"Board not found."}`)
+		default:
+			deleted = append(deleted, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("token")
+	client.baseUrl = server.URL + "/"
+
+	err := client.DeleteBoards(context.Background(), "test(")
+	assert.Error(t, err)
+	assert.Nil(t, deleted)
+
+	err = client.DeleteBoards(context.Background(), `test\d`)
+	deleteErr, ok := err.(*DeleteBoardsError)
+	if assert.True(t, ok) {
+		assert.Equal(t, 1, len(deleteErr.Errors))
+		assert.True(t, IsNotFound(deleteErr.Errors[0]))
+	}
+	assert.Equal(t, []string{"/boards/1"}, deleted)
+}
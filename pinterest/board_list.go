@@ -8,46 +8,73 @@ import (
 	"github.com/go-logr/logr"
 )
 
-type listBoardResponseBody struct {
-	Items    []boardRequestBody `json:"items"`
-	Bookmark string             `json:"bookmark"`
+// BoardIterator streams the boards of the account page by page.
+//
+//	it := client.IterateBoards(ctx)
+//	for it.Next() {
+//		board := it.Board()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+type BoardIterator struct {
+	ctx   context.Context
+	pager *pager
+	board BoardInfo
+	err   error
 }
 
-func (c *Client) ListBoards(ctx context.Context) ([]BoardInfo, error) {
-	listBoardResponseBody, err := c.doListBoards(ctx)
-	if err != nil {
-		return nil, err
+func (c *Client) IterateBoards(ctx context.Context, opts ...ListOption) *BoardIterator {
+	return &BoardIterator{
+		ctx:   ctx,
+		pager: c.newPager("boards", nil, newListOptions(opts)),
 	}
+}
 
-	boardInfos := make([]BoardInfo, 0, len(listBoardResponseBody.Items))
-	for _, item := range listBoardResponseBody.Items {
-		boardInfos = append(boardInfos, BoardInfo{
-			Id:   item.Id,
-			Name: item.Name,
-		})
+// Next advances to the next board. It returns false when there are no more
+// boards or an error occurred.
+func (it *BoardIterator) Next() bool {
+	if it.err != nil {
+		return false
 	}
 
-	return boardInfos, nil
-}
-
-func (c *Client) doListBoards(ctx context.Context) (listBoardResponseBody, error) {
-	url := c.buildBoardsURL()
-	req, err := c.createRequest("GET", url, nil)
-	if err != nil {
-		return listBoardResponseBody{}, fmt.Errorf("error creating request: %v", err)
+	item, ok := it.pager.next(it.ctx)
+	if !ok {
+		it.err = it.pager.err
+		return false
 	}
 
-	responseBody, err := c.executeRequest(ctx, req, 200)
-	if err != nil {
-		return listBoardResponseBody{}, fmt.Errorf("error executing request: %v", err)
+	var body boardRequestBody
+	if err := json.Unmarshal(item, &body); err != nil {
+		it.err = fmt.Errorf("unable to unmarshal board: %v", err)
+		return false
 	}
 
-	var listBoardResponseBody listBoardResponseBody
-	if err := json.Unmarshal(responseBody, &listBoardResponseBody); err != nil {
-		return listBoardResponseBody, fmt.Errorf("unable to unmarshal response body: %v", err)
+	it.board = body.toBoardInfo()
+	return true
+}
+
+func (it *BoardIterator) Board() BoardInfo {
+	return it.board
+}
+
+func (it *BoardIterator) Err() error {
+	return it.err
+}
+
+// ListBoards returns all boards of the account, following the bookmark of
+// every page.
+func (c *Client) ListBoards(ctx context.Context, opts ...ListOption) ([]BoardInfo, error) {
+	boardInfos := []BoardInfo{}
+
+	it := c.IterateBoards(ctx, opts...)
+	for it.Next() {
+		boardInfos = append(boardInfos, it.Board())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
 	}
 
-	return listBoardResponseBody, nil
+	return boardInfos, nil
 }
 
 func BoardIdByName(boards []BoardInfo, boardName string) (string, error) {
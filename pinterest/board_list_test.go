@@ -0,0 +1,55 @@
+package pinterest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"pin-creator/pinterest/cassette"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListBoardsFollowsBookmarks(t *testing.T) {
+	var pageSizes []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/boards", r.URL.Path)
+		pageSizes = append(pageSizes, r.URL.Query().Get("page_size"))
+
+		switch r.URL.Query().Get("bookmark") {
+		case "":
+			fmt.Fprint(w, `{"items":[{"id":"1","name":"first"},{"id":"2","name":"second"}],"bookmark":"page2"}`)
+		case "page2":
+			fmt.Fprint(w, `{"items":[{"id":"3","name":"third"}],"bookmark":null}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("token")
+	client.baseUrl = server.URL + "/"
+
+	boards, err := client.ListBoards(context.Background(), WithPageSize(2))
+	assert.NoError(t, err)
+	assert.Equal(t, []BoardInfo{{Id: "1", Name: "first"}, {Id: "2", Name: "second"}, {Id: "3", Name: "third"}}, boards)
+	assert.Equal(t, []string{"2", "2"}, pageSizes)
+
+	it := client.IterateBoards(context.Background())
+	assert.True(t, it.Next())
+	assert.Equal(t, "first", it.Board().Name)
+	assert.Equal(t, 3, len(pageSizes))
+}
+
+func TestListBoardsFromCassette(t *testing.T) {
+	replayer, err := cassette.NewReplayer("testdata/list_boards.yaml")
+	assert.NoError(t, err)
+
+	client := NewClient("", WithTransport(replayer))
+
+	boards, err := client.ListBoards(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []BoardInfo{{Id: "1", Name: "first"}, {Id: "2", Name: "second"}}, boards)
+}
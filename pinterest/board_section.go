@@ -0,0 +1,145 @@
+package pinterest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+)
+
+type ErrBoardSectionNotFound struct {
+	SectionName string
+}
+
+func (e ErrBoardSectionNotFound) Error() string {
+	return fmt.Sprintf("board section %s not found", e.SectionName)
+}
+
+type boardSectionRequestBody struct {
+	Name string `json:"name"`
+}
+
+// ListBoardSections returns all sections of the board, following the bookmark
+// of every page.
+func (c *Client) ListBoardSections(ctx context.Context, boardId string, opts ...ListOption) ([]BoardSection, error) {
+	sections := []BoardSection{}
+
+	p := c.newPager(fmt.Sprintf("boards/%s/sections", boardId), nil, newListOptions(opts))
+	for {
+		item, ok := p.next(ctx)
+		if !ok {
+			break
+		}
+
+		var section BoardSection
+		if err := json.Unmarshal(item, &section); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal board section: %v", err)
+		}
+		sections = append(sections, section)
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	return sections, nil
+}
+
+func (c *Client) CreateBoardSection(ctx context.Context, boardId string, name string) (*BoardSection, error) {
+	url := fmt.Sprintf("%sboards/%s/sections", c.baseUrl, boardId)
+	return c.doBoardSectionRequest(ctx, "POST", url, name, 201)
+}
+
+func (c *Client) RenameBoardSection(ctx context.Context, boardId string, sectionId string, name string) (*BoardSection, error) {
+	url := fmt.Sprintf("%sboards/%s/sections/%s", c.baseUrl, boardId, sectionId)
+	return c.doBoardSectionRequest(ctx, "PATCH", url, name, 200)
+}
+
+func (c *Client) DeleteBoardSection(ctx context.Context, boardId string, sectionId string) error {
+	url := fmt.Sprintf("%sboards/%s/sections/%s", c.baseUrl, boardId, sectionId)
+
+	req, err := c.createRequest("DELETE", url, nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+
+	_, err = c.executeRequest(ctx, req, 204)
+	if err != nil {
+		return fmt.Errorf("error executing request: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) doBoardSectionRequest(ctx context.Context, method string, url string, name string, expectedStatus int) (*BoardSection, error) {
+	req, err := c.createRequest(method, url, boardSectionRequestBody{Name: name})
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	responseBody, err := c.executeRequest(ctx, req, expectedStatus)
+	if err != nil {
+		return nil, fmt.Errorf("error executing request: %w", err)
+	}
+
+	var section BoardSection
+	if err := json.Unmarshal(responseBody, &section); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal response body: %v", err)
+	}
+
+	return &section, nil
+}
+
+func findBoardSection(ctx context.Context, client ClientInterface, log logr.Logger, boardId string, sectionName string) (string, error) {
+	log.V(2).Info("Attempting to list board sections", "boardId", boardId)
+	sections, err := client.ListBoardSections(ctx, boardId)
+	if err != nil {
+		return "", fmt.Errorf("error listing board sections: %w", err)
+	}
+
+	for _, section := range sections {
+		if section.Name == sectionName {
+			log.V(2).Info("board section found", "sectionName", sectionName, "sectionId", section.Id)
+			return section.Id, nil
+		}
+	}
+
+	return "", ErrBoardSectionNotFound{SectionName: sectionName}
+}
+
+func findOrCreateBoardSection(ctx context.Context, client ClientInterface, log logr.Logger, boardId string, sectionName string) (string, error) {
+	sectionId, err := findBoardSection(ctx, client, log, boardId, sectionName)
+	if err == nil {
+		return sectionId, nil
+	}
+
+	if _, ok := err.(ErrBoardSectionNotFound); !ok {
+		return "", fmt.Errorf("error finding board section: %w", err)
+	}
+
+	log.V(1).Info("Board section not found. Creating new section.", "sectionName", sectionName)
+	section, err := client.CreateBoardSection(ctx, boardId, sectionName)
+	if err != nil {
+		return "", fmt.Errorf("error creating board section: %w", err)
+	}
+
+	return section.Id, nil
+}
+
+// CreateOrFindBoardSection returns the id of the section of the board and
+// creates the section if it doesn't exist.
+func CreateOrFindBoardSection(ctx context.Context, client ClientInterface, log logr.Logger, boardId string, sectionName string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultResolveTimeout)
+	defer cancel()
+
+	sectionId, err := findOrCreateBoardSection(ctx, client, log, boardId, sectionName)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return "", fmt.Errorf("timeout occurred while trying to create or find board section: %w", err)
+		}
+		return "", fmt.Errorf("failed to create or find board section: %w", err)
+	}
+
+	return sectionId, nil
+}
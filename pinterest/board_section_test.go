@@ -0,0 +1,75 @@
+package pinterest
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"pin-creator/pinterest/pinteresttest"
+)
+
+func TestCreateOrFindBoardSection(t *testing.T) {
+	fake := pinteresttest.NewServer()
+	defer fake.Close()
+	client := NewClient(fake.Token(), WithApiUrl(fake.URL))
+	ctx := context.Background()
+
+	boardId := fake.AddBoard("board", "")
+	dinnerId := fake.AddSection(boardId, "dinner")
+
+	sectionId, err := CreateOrFindBoardSection(ctx, client, logr.Discard(), boardId, "dinner")
+	assert.NoError(t, err)
+	assert.Equal(t, dinnerId, sectionId)
+
+	sectionId, err = CreateOrFindBoardSection(ctx, client, logr.Discard(), boardId, "lunch")
+	assert.NoError(t, err)
+	assert.NotEqual(t, dinnerId, sectionId)
+	assert.Equal(t, []pinteresttest.Section{{Id: dinnerId, Name: "dinner"}, {Id: sectionId, Name: "lunch"}}, fake.Sections(boardId))
+}
+
+func TestSectionCacheAfterRenameAndDelete(t *testing.T) {
+	fake := pinteresttest.NewServer()
+	defer fake.Close()
+	client := NewClient(fake.Token(), WithApiUrl(fake.URL))
+	ctx := context.Background()
+
+	boardId := fake.AddBoard("board", "")
+	dinnerId := fake.AddSection(boardId, "dinner")
+
+	cache, err := NewBoardCache(filepath.Join(t.TempDir(), "boards.json"), time.Hour)
+	assert.NoError(t, err)
+
+	sectionId, cached, err := ResolveBoardSection(ctx, client, cache, logr.Discard(), "board", boardId, "dinner")
+	assert.NoError(t, err)
+	assert.False(t, cached)
+	assert.Equal(t, dinnerId, sectionId)
+
+	// renaming moves the cached id, the old name no longer resolves to it
+	section, err := client.RenameBoardSection(ctx, boardId, dinnerId, "supper")
+	assert.NoError(t, err)
+	assert.NoError(t, cache.RenameSection("board", "dinner", section.Name, section.Id))
+
+	sectionId, cached, err = ResolveBoardSection(ctx, client, cache, logr.Discard(), "board", boardId, "supper")
+	assert.NoError(t, err)
+	assert.True(t, cached)
+	assert.Equal(t, dinnerId, sectionId)
+
+	sectionId, cached, err = ResolveBoardSection(ctx, client, cache, logr.Discard(), "board", boardId, "dinner")
+	assert.NoError(t, err)
+	assert.False(t, cached)
+	assert.NotEqual(t, dinnerId, sectionId)
+
+	// deleting drops the cached id, so the section is created again
+	assert.NoError(t, client.DeleteBoardSection(ctx, boardId, dinnerId))
+	assert.NoError(t, cache.InvalidateSection("board", "supper"))
+
+	sectionId, cached, err = ResolveBoardSection(ctx, client, cache, logr.Discard(), "board", boardId, "supper")
+	assert.NoError(t, err)
+	assert.False(t, cached)
+	assert.NotEqual(t, dinnerId, sectionId)
+	assert.Equal(t, 2, len(fake.Sections(boardId)))
+}
@@ -1,8 +1,24 @@
 package pinterest
 
+import (
+	"time"
+)
+
+const (
+	PrivacyPublic    = "PUBLIC"
+	PrivacyProtected = "PROTECTED"
+	PrivacySecret    = "SECRET"
+)
+
 type BoardInfo struct {
-	Id   string
-	Name string
+	Id            string
+	Name          string
+	Description   string
+	Privacy       string
+	PinCount      int
+	FollowerCount int
+	CreatedAt     time.Time
+	Owner         BoardOwner
 }
 
 type BoardData struct {
@@ -10,3 +26,38 @@ type BoardData struct {
 	Description string `json:"description"`
 	Privacy     string `json:"privacy"`
 }
+
+// BoardTemplate describes how a board that is missing for a schedule row gets
+// created.
+type BoardTemplate struct {
+	BoardData
+	// Sections are created right after the board.
+	Sections []string
+	// NoCreate makes resolving a missing board fail with ErrBoardNotFound
+	// instead of creating it.
+	NoCreate bool
+}
+
+// BoardUpdate holds the fields of a board to change. Nil fields are left
+// untouched.
+type BoardUpdate struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Privacy     *string `json:"privacy,omitempty"`
+}
+
+// ValidPrivacy reports whether privacy is one of the privacy settings Pinterest
+// accepts for boards.
+func ValidPrivacy(privacy string) bool {
+	switch privacy {
+	case PrivacyPublic, PrivacyProtected, PrivacySecret:
+		return true
+	default:
+		return false
+	}
+}
+
+type BoardSection struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
@@ -0,0 +1,55 @@
+package pinterest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+func (c *Client) GetBoard(ctx context.Context, boardId string) (*BoardInfo, error) {
+	url := fmt.Sprintf("%s%s/%s", c.baseUrl, "boards", boardId)
+
+	req, err := c.createRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	responseBody, err := c.executeRequest(ctx, req, 200)
+	if err != nil {
+		return nil, fmt.Errorf("error executing request: %w", err)
+	}
+
+	return parseBoard(responseBody)
+}
+
+// UpdateBoard changes the name, description or privacy of the board and
+// returns the updated board.
+func (c *Client) UpdateBoard(ctx context.Context, boardId string, update BoardUpdate) (*BoardInfo, error) {
+	if update.Privacy != nil && !ValidPrivacy(*update.Privacy) {
+		return nil, fmt.Errorf("invalid board privacy %s", *update.Privacy)
+	}
+
+	url := fmt.Sprintf("%s%s/%s", c.baseUrl, "boards", boardId)
+
+	req, err := c.createRequest("PATCH", url, update)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	responseBody, err := c.executeRequest(ctx, req, 200)
+	if err != nil {
+		return nil, fmt.Errorf("error executing request: %w", err)
+	}
+
+	return parseBoard(responseBody)
+}
+
+func parseBoard(responseBody []byte) (*BoardInfo, error) {
+	var board boardRequestBody
+	if err := json.Unmarshal(responseBody, &board); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal response body: %v", err)
+	}
+
+	boardInfo := board.toBoardInfo()
+	return &boardInfo, nil
+}
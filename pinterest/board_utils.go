@@ -5,21 +5,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-
-	"pin-creator/internal/logger"
 )
 
-func (c *Client) buildBoardsURL() string {
-	return fmt.Sprintf("%s%s", c.baseUrl, "boards")
-}
-
 func (c *Client) addRequestHeaders(req *http.Request) {
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", c.accessToken))
 	req.Header.Add("Content-Type", "application/json")
 }
 
 func (c *Client) logRequestDetails(ctx context.Context, req *http.Request) {
-	log := logger.FromContext(ctx)
+	log := c.log(ctx)
 	reqDump := struct {
 		Method  string      `json:"method"`
 		URL     string      `json:"url"`
@@ -38,7 +31,7 @@ func (c *Client) logRequestDetails(ctx context.Context, req *http.Request) {
 }
 
 func (c *Client) logResponse(ctx context.Context, bodyBytes []byte) {
-	log := logger.FromContext(ctx)
+	log := c.log(ctx)
 	var response interface{}
 	if err := json.Unmarshal(bodyBytes, &response); err != nil {
 		log.Error(err, "unable to unmarshal response body")
@@ -48,7 +41,7 @@ func (c *Client) logResponse(ctx context.Context, bodyBytes []byte) {
 }
 
 func (c *Client) prettyPrintJSON(ctx context.Context, data interface{}) {
-	log := logger.FromContext(ctx)
+	log := c.log(ctx)
 	prettyJSON, err := json.MarshalIndent(data, "", "    ")
 	if err != nil {
 		log.Error(err, "error marshaling JSON with indentation")
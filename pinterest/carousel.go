@@ -0,0 +1,57 @@
+package pinterest
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	minCarouselItems = 2
+	maxCarouselItems = 5
+)
+
+// carouselMediaSource builds a multiple image media source. The images are
+// passed as URLs if all of them are URLs that Pinterest may fetch, otherwise
+// all of them are uploaded.
+func (c *Client) carouselMediaSource(ctx context.Context, pinData PinData) (mediaSourceRequestBody, error) {
+	if len(pinData.Items) < minCarouselItems || len(pinData.Items) > maxCarouselItems {
+		return mediaSourceRequestBody{}, fmt.Errorf("carousel pins need %d to %d images, got %d", minCarouselItems, maxCarouselItems, len(pinData.Items))
+	}
+
+	download := pinData.DownloadImageUrl
+	for _, item := range pinData.Items {
+		if IsVideo(item.ImgPath) {
+			return mediaSourceRequestBody{}, fmt.Errorf("carousel pins can't contain video %s", item.ImgPath)
+		}
+		if !IsImageUrl(item.ImgPath) {
+			download = true
+		}
+	}
+
+	mediaSource := mediaSourceRequestBody{SourceType: "multiple_image_urls"}
+	if download {
+		mediaSource.SourceType = "multiple_image_base64"
+	}
+
+	for _, item := range pinData.Items {
+		itemSource, err := c.pinMediaSource(ctx, PinData{
+			ImgPath:          item.ImgPath,
+			ConvertTo:        pinData.ConvertTo,
+			DownloadImageUrl: download,
+		})
+		if err != nil {
+			return mediaSourceRequestBody{}, err
+		}
+
+		mediaSource.Items = append(mediaSource.Items, carouselItemRequestBody{
+			Title:       item.Title,
+			Description: item.Description,
+			Link:        item.Link,
+			ContentType: itemSource.ContentType,
+			Data:        itemSource.Data,
+			Url:         itemSource.Url,
+		})
+	}
+
+	return mediaSource, nil
+}
@@ -0,0 +1,43 @@
+package pinterest
+
+import (
+	"context"
+	"image"
+	"image/jpeg"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCarouselMediaSource(t *testing.T) {
+	client := NewClient("token")
+
+	mediaSource, err := client.pinMediaSource(context.Background(), PinData{Items: []PinItem{
+		{ImgPath: "https://example.com/a.png", Title: "A"},
+		{ImgPath: "https://example.com/b.png", Link: "https://example.com/b"},
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, mediaSourceRequestBody{
+		SourceType: "multiple_image_urls",
+		Items: []carouselItemRequestBody{
+			{Title: "A", Url: "https://example.com/a.png"},
+			{Link: "https://example.com/b", Url: "https://example.com/b.png"},
+		},
+	}, mediaSource)
+
+	jpegPath := writeTestImage(t, "image.jpg", func(f *os.File, img image.Image) error {
+		return jpeg.Encode(f, img, nil)
+	})
+	mediaSource, err = client.pinMediaSource(context.Background(), PinData{Items: []PinItem{
+		{ImgPath: jpegPath},
+		{ImgPath: jpegPath},
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, "multiple_image_base64", mediaSource.SourceType)
+	assert.Equal(t, 2, len(mediaSource.Items))
+	assert.Equal(t, ContentTypeJPEG, mediaSource.Items[1].ContentType)
+
+	_, err = client.pinMediaSource(context.Background(), PinData{Items: []PinItem{{ImgPath: jpegPath}}})
+	assert.EqualError(t, err, "carousel pins need 2 to 5 images, got 1")
+}
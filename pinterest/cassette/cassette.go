@@ -0,0 +1,66 @@
+// Package cassette records the requests of the Pinterest client with their
+// responses to a file and replays them. Access tokens and image data are
+// redacted before anything is written.
+package cassette
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Cassette is a recording of requests and their responses in the order they
+// were sent.
+type Cassette struct {
+	Interactions []Interaction `yaml:"interactions"`
+}
+
+type Interaction struct {
+	Request  Request  `yaml:"request"`
+	Response Response `yaml:"response"`
+}
+
+type Request struct {
+	Method  string      `yaml:"method"`
+	URL     string      `yaml:"url"`
+	Headers http.Header `yaml:"headers,omitempty"`
+	Body    string      `yaml:"body,omitempty"`
+}
+
+// Response holds the response of a request, or the error if the request
+// failed without response.
+type Response struct {
+	StatusCode int         `yaml:"status_code,omitempty"`
+	Headers    http.Header `yaml:"headers,omitempty"`
+	Body       string      `yaml:"body,omitempty"`
+	Error      string      `yaml:"error,omitempty"`
+}
+
+func Load(filePath string) (*Cassette, error) {
+	yamlFile, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read cassette. Error: %s", err.Error())
+	}
+
+	c := &Cassette{}
+	err = yaml.Unmarshal(yamlFile, c)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse cassette. Error: %s", err.Error())
+	}
+
+	return c, nil
+}
+
+func (c *Cassette) Save(filePath string) error {
+	bytes, err := yaml.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("unable to marshal cassette: %v", err)
+	}
+
+	if err := os.WriteFile(filePath, bytes, 0o600); err != nil {
+		return fmt.Errorf("unable to write cassette: %w", err)
+	}
+	return nil
+}
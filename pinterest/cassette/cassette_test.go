@@ -0,0 +1,62 @@
+package cassette
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, `{"title":"pin","media_source":{"data":"aW1hZ2U="}}`, string(body))
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret")
+		fmt.Fprint(w, `{"id":"42","access_token":"secret"}`)
+	}))
+	defer server.Close()
+
+	cassettePath := filepath.Join(t.TempDir(), "cassette.yaml")
+	recorder := NewRecorder(cassettePath)
+	client := &http.Client{Transport: recorder.Middleware()(http.DefaultTransport)}
+
+	req, _ := http.NewRequest(http.MethodPost, server.URL+"/pins", strings.NewReader(`{"title":"pin","media_source":{"data":"aW1hZ2U="}}`))
+	req.Header.Set("Authorization", "Bearer secret")
+	res, err := client.Do(req)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(res.Body)
+	assert.Equal(t, `{"id":"42","access_token":"secret"}`, string(body))
+
+	c, err := Load(cassettePath)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(c.Interactions))
+	recorded := c.Interactions[0]
+	assert.Equal(t, "REDACTED", recorded.Request.Headers.Get("Authorization"))
+	assert.Equal(t, `{"media_source":{"data":"REDACTED (8 bytes)"},"title":"pin"}`, recorded.Request.Body)
+	assert.Equal(t, "REDACTED", recorded.Response.Headers.Get("Set-Cookie"))
+	assert.Equal(t, `{"access_token":"REDACTED (6 bytes)","id":"42"}`, recorded.Response.Body)
+
+	replayer, err := NewReplayer(cassettePath)
+	assert.NoError(t, err)
+	client = &http.Client{Transport: replayer}
+
+	res, err = client.Post(server.URL+"/pins", "application/json", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	body, _ = io.ReadAll(res.Body)
+	assert.Equal(t, `{"access_token":"REDACTED (6 bytes)","id":"42"}`, string(body))
+
+	_, err = client.Post(server.URL+"/pins", "application/json", nil)
+	assert.Error(t, err)
+}
+
+func TestSanitizeFormBody(t *testing.T) {
+	body := sanitizeBody([]byte("grant_type=refresh_token&refresh_token=secret"), "application/x-www-form-urlencoded")
+	assert.Equal(t, "grant_type=refresh_token&refresh_token=REDACTED", body)
+}
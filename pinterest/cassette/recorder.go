@@ -0,0 +1,87 @@
+package cassette
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// Recorder records every request passing through its middleware. The cassette
+// is written after every interaction, so a failed run leaves everything up to
+// the failure on disk.
+type Recorder struct {
+	mu       sync.Mutex
+	filePath string
+	cassette Cassette
+}
+
+func NewRecorder(filePath string) *Recorder {
+	return &Recorder{filePath: filePath}
+}
+
+// Middleware returns the recording middleware for pinterest.WithMiddleware.
+// Request bodies are read into memory to be recorded.
+func (r *Recorder) Middleware() func(next http.RoundTripper) http.RoundTripper {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			var reqBody []byte
+			if req.Body != nil {
+				var err error
+				reqBody, err = io.ReadAll(req.Body)
+				req.Body.Close()
+				if err != nil {
+					return nil, err
+				}
+				req = req.Clone(req.Context())
+				req.Body = io.NopCloser(bytes.NewReader(reqBody))
+			}
+
+			interaction := Interaction{
+				Request: Request{
+					Method:  req.Method,
+					URL:     req.URL.String(),
+					Headers: sanitizeHeaders(req.Header),
+					Body:    sanitizeBody(reqBody, req.Header.Get("Content-Type")),
+				},
+			}
+
+			res, err := next.RoundTrip(req)
+			if err != nil {
+				interaction.Response.Error = err.Error()
+				return nil, r.record(interaction, err)
+			}
+
+			resBody, err := io.ReadAll(res.Body)
+			res.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			res.Body = io.NopCloser(bytes.NewReader(resBody))
+
+			interaction.Response.StatusCode = res.StatusCode
+			interaction.Response.Headers = sanitizeHeaders(res.Header)
+			interaction.Response.Body = sanitizeBody(resBody, res.Header.Get("Content-Type"))
+			return res, r.record(interaction, nil)
+		})
+	}
+}
+
+// record appends the interaction and saves the cassette. Errors of the
+// request take precedence over errors writing the cassette.
+func (r *Recorder) record(interaction Interaction, requestErr error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	if err := r.cassette.Save(r.filePath); err != nil && requestErr == nil {
+		return err
+	}
+	return requestErr
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,72 @@
+package cassette
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Replayer is a http.RoundTripper that answers requests with the responses of
+// a cassette. Requests are matched by method and URL, each interaction is
+// used once and in order.
+type Replayer struct {
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+func NewReplayer(filePath string) (*Replayer, error) {
+	c, err := Load(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return NewReplayerFrom(c), nil
+}
+
+func NewReplayerFrom(c *Cassette) *Replayer {
+	return &Replayer{
+		cassette: c,
+		used:     make([]bool, len(c.Interactions)),
+	}
+}
+
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || interaction.Request.Method != req.Method || interaction.Request.URL != req.URL.String() {
+			continue
+		}
+		r.used[i] = true
+
+		if interaction.Response.Error != "" {
+			return nil, errors.New(interaction.Response.Error)
+		}
+
+		headers := interaction.Response.Headers.Clone()
+		if headers == nil {
+			headers = http.Header{}
+		}
+		return &http.Response{
+			StatusCode:    interaction.Response.StatusCode,
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			Header:        headers,
+			Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no recorded response for %s %s", req.Method, req.URL.String())
+}
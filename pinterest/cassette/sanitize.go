@@ -0,0 +1,97 @@
+package cassette
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const redacted = "REDACTED"
+
+// redactedHeaders are replaced in recorded requests and responses.
+var redactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// redactedFields are replaced in JSON bodies. Image data is replaced by its
+// size to keep cassettes small.
+var redactedFields = map[string]bool{
+	"access_token":     true,
+	"refresh_token":    true,
+	"data":             true,
+	"cover_image_data": true,
+}
+
+// redactedFormFields are replaced in the form bodies of OAuth requests.
+var redactedFormFields = map[string]bool{
+	"access_token":  true,
+	"refresh_token": true,
+	"code":          true,
+	"client_secret": true,
+}
+
+func sanitizeHeaders(headers http.Header) http.Header {
+	if len(headers) == 0 {
+		return nil
+	}
+
+	sanitized := headers.Clone()
+	for _, name := range redactedHeaders {
+		if sanitized.Get(name) != "" {
+			sanitized.Set(name, redacted)
+		}
+	}
+	return sanitized
+}
+
+// sanitizeBody redacts the secrets and image data of JSON and form bodies and
+// replaces uploaded files by their size. Other bodies are kept as they are.
+func sanitizeBody(body []byte, contentType string) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err == nil {
+		sanitized, err := json.Marshal(sanitizeValue(value))
+		if err == nil {
+			return string(sanitized)
+		}
+	}
+
+	if strings.HasPrefix(contentType, "multipart/form-data") {
+		return fmt.Sprintf("%s (%d bytes)", redacted, len(body))
+	}
+
+	if contentType == "application/x-www-form-urlencoded" {
+		if form, err := url.ParseQuery(string(body)); err == nil {
+			for key := range form {
+				if redactedFormFields[key] {
+					form.Set(key, redacted)
+				}
+			}
+			return form.Encode()
+		}
+	}
+
+	return string(body)
+}
+
+func sanitizeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			s, isString := field.(string)
+			if redactedFields[key] && isString {
+				v[key] = fmt.Sprintf("%s (%d bytes)", redacted, len(s))
+				continue
+			}
+			v[key] = sanitizeValue(field)
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = sanitizeValue(v[i])
+		}
+	}
+	return value
+}
@@ -3,29 +3,91 @@ package pinterest
 import (
 	"context"
 	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"pin-creator/internal/logger"
 )
 
 const (
-	baseUrl = "https://api-sandbox.pinterest.com/v5/"
+	baseUrl = sandboxApiUrl + "/" + apiVersionPath
 )
 
 type ClientInterface interface {
-	CreatePin(ctx context.Context, pinData PinData) error
-	ListBoards(ctx context.Context) ([]BoardInfo, error)
-	CreateBoard(ctx context.Context, boardData BoardData) error
+	CreatePin(ctx context.Context, pinData PinData) (*Pin, error)
+	GetPin(ctx context.Context, pinId string) (*Pin, error)
+	ListPins(ctx context.Context, boardId string, opts ...ListOption) ([]Pin, error)
+	UpdatePin(ctx context.Context, pinId string, update PinUpdate) (*Pin, error)
+	DeletePin(ctx context.Context, pinId string) error
+	ListBoards(ctx context.Context, opts ...ListOption) ([]BoardInfo, error)
+	CreateBoard(ctx context.Context, boardData BoardData) (*BoardInfo, error)
+	GetBoard(ctx context.Context, boardId string) (*BoardInfo, error)
+	UpdateBoard(ctx context.Context, boardId string, update BoardUpdate) (*BoardInfo, error)
+	DeleteBoard(ctx context.Context, boardId string) error
 	DeleteBoards(ctx context.Context, regex string) error
+	ListBoardSections(ctx context.Context, boardId string, opts ...ListOption) ([]BoardSection, error)
+	CreateBoardSection(ctx context.Context, boardId string, name string) (*BoardSection, error)
+	RenameBoardSection(ctx context.Context, boardId string, sectionId string, name string) (*BoardSection, error)
+	DeleteBoardSection(ctx context.Context, boardId string, sectionId string) error
+	GetMedia(ctx context.Context, mediaId string) (*UploadedMedia, error)
+	RateLimits() []RateLimit
 }
 
+// Client calls the Pinterest API. API requests pass through the middlewares
+// and the authentication of the client, image and video transfers to other
+// hosts only pass through the middlewares.
 type Client struct {
-	httpClient  *http.Client
-	accessToken string
-	baseUrl     string
+	httpClient     *http.Client
+	apiClient      *http.Client
+	transferClient *http.Client
+	tokenSource    TokenSource
+	baseUrl        string
+	userAgent      string
+	timeout        time.Duration
+	logger         *logr.Logger
+	middlewares    []Middleware
+
+	mediaPollInterval time.Duration
+	mediaTimeout      time.Duration
+	rateLimiter       *rateLimiter
+	retryPolicy       RetryPolicy
+}
+
+func NewClient(accessToken string, opts ...ClientOption) *Client {
+	c := &Client{
+		httpClient:        &http.Client{},
+		tokenSource:       StaticTokenSource(accessToken),
+		baseUrl:           baseUrl,
+		userAgent:         defaultUserAgent,
+		mediaPollInterval: defaultMediaPollInterval,
+		mediaTimeout:      defaultMediaTimeout,
+		rateLimiter:       newRateLimiter(RateLimitPolicy{}),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	c.apiClient = &http.Client{
+		Transport:     c.transport(),
+		CheckRedirect: c.httpClient.CheckRedirect,
+		Jar:           c.httpClient.Jar,
+		Timeout:       c.httpClient.Timeout,
+	}
+	c.transferClient = &http.Client{
+		Transport:     c.transferTransport(),
+		CheckRedirect: c.httpClient.CheckRedirect,
+		Jar:           c.httpClient.Jar,
+		Timeout:       c.httpClient.Timeout,
+	}
+	return c
 }
 
-func NewClient(accessToken string) *Client {
-	return &Client{
-		httpClient:  &http.Client{},
-		accessToken: accessToken,
-		baseUrl:     baseUrl,
+// log returns the logger of the client, or the logger of the context if the
+// client has none.
+func (c *Client) log(ctx context.Context) logr.Logger {
+	if c.logger != nil {
+		return *c.logger
 	}
+	return logger.FromContext(ctx)
 }
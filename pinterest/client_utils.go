@@ -10,46 +10,112 @@ import (
 )
 
 func (c *Client) createRequest(method, url string, body interface{}) (*http.Request, error) {
+	var bodyBytes []byte
 	var bodyReader io.Reader
 	if body != nil {
-		bodyBytes, err := json.Marshal(body)
+		var err error
+		bodyBytes, err = json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("unable to marshal body: %v", err)
 		}
 		bodyReader = bytes.NewBuffer(bodyBytes)
 	}
 
+	segments, length, err := splitRequestBody(bodyBytes)
+	if err != nil {
+		return nil, err
+	}
+
 	req, err := http.NewRequest(method, url, bodyReader)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create new http request: %v", err)
 	}
 
+	// bodies with image files are streamed instead of being held in memory
+	if segments != nil {
+		req.Body = streamRequestBody(segments)
+		req.ContentLength = length
+		req.GetBody = func() (io.ReadCloser, error) {
+			return streamRequestBody(segments), nil
+		}
+	}
+
 	c.addRequestHeaders(req)
 	return req, nil
 }
 
+// executeRequest sends the request through the middlewares of the client,
+// which retry and rate limit it, and checks the status of the response.
 func (c *Client) executeRequest(ctx context.Context, req *http.Request, expectedStatus int) ([]byte, error) {
 	c.logRequestDetails(ctx, req)
 
-	res, err := c.httpClient.Do(req.WithContext(ctx))
+	res, err := c.apiClient.Do(req.WithContext(ctx))
 	if err != nil {
-		return nil, fmt.Errorf("unable to send request: %v", err)
+		return nil, fmt.Errorf("unable to send request: %w", err)
 	}
 	defer res.Body.Close()
 
 	bodyBytes, err := io.ReadAll(res.Body)
 	if err != nil {
-		return nil, fmt.Errorf("unable to read response body: %v", err)
+		return nil, fmt.Errorf("unable to read response body: %w", err)
 	}
 
-	if res.StatusCode != expectedStatus {
-		errorResponse, err := handleWrongStatuscode(res)
-		if err != nil {
-			return nil, err
-		}
-		return nil, fmt.Errorf("unexpected status code %d. ErrorCode: %d ErrorMessage: %s", res.StatusCode, errorResponse.Code, errorResponse.Message)
+	err = statusError(res, bodyBytes, expectedStatus, c.rateLimitCategory(req))
+	if err != nil {
+		return nil, err
 	}
 
 	c.logResponse(ctx, bodyBytes)
 	return bodyBytes, nil
 }
+
+// attemptMiddleware waits for the rate limit, limits a single attempt to the
+// timeout of the client and reads the response. Reading it here makes a
+// response that gets lost while reading fail the attempt, so the retry
+// middleware can decide whether to send the request again.
+func (c *Client) attemptMiddleware() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			category := c.rateLimitCategory(req)
+			if err := c.rateLimiter.wait(req.Context(), category); err != nil {
+				if req.Body != nil {
+					req.Body.Close()
+				}
+				return nil, err
+			}
+
+			// an attempt that times out may be retried, a canceled request may not
+			if c.timeout > 0 {
+				ctx, cancel := context.WithTimeout(req.Context(), c.timeout)
+				defer cancel()
+				req = req.WithContext(ctx)
+			}
+
+			res, err := next.RoundTrip(req)
+			if err != nil {
+				return nil, err
+			}
+			c.rateLimiter.update(category, res)
+
+			bodyBytes, err := io.ReadAll(res.Body)
+			res.Body.Close()
+			if err != nil {
+				return nil, fmt.Errorf("unable to read response body: %w", err)
+			}
+			res.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+			return res, nil
+		})
+	}
+}
+
+func statusError(res *http.Response, bodyBytes []byte, expectedStatus int, category string) error {
+	if res.StatusCode == expectedStatus {
+		return nil
+	}
+
+	apiErr := newAPIError(res, bodyBytes)
+	if res.StatusCode == http.StatusTooManyRequests {
+		return fmt.Errorf("retry %s requests after %s: %w", category, retryAfter(res), apiErr)
+	}
+	return apiErr
+}
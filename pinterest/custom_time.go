@@ -10,6 +10,9 @@ type CustomTime struct {
 
 func (ct *CustomTime) UnmarshalJSON(b []byte) error {
 	s := string(b)
+	if s == "null" {
+		return nil
+	}
 	t, err := time.Parse(`"2006-01-02T15:04:05"`, s)
 	if err != nil {
 		return err
@@ -0,0 +1,38 @@
+package pinterest
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	EnvironmentSandbox    = "sandbox"
+	EnvironmentProduction = "production"
+
+	sandboxApiUrl    = "https://api-sandbox.pinterest.com"
+	productionApiUrl = "https://api.pinterest.com"
+	apiVersionPath   = "v5/"
+)
+
+// ApiUrl returns the API host of the environment. A custom URL replaces the
+// host of the environment, e.g. for a proxy. Pins created in the sandbox are
+// only visible to the developer of the app.
+func ApiUrl(environment string, customUrl string) (string, error) {
+	switch environment {
+	case "", EnvironmentSandbox, EnvironmentProduction:
+	default:
+		return "", fmt.Errorf("unknown environment %s, use %s or %s", environment, EnvironmentSandbox, EnvironmentProduction)
+	}
+
+	if customUrl != "" {
+		return strings.TrimSuffix(customUrl, "/"), nil
+	}
+	if environment == EnvironmentProduction {
+		return productionApiUrl, nil
+	}
+	return sandboxApiUrl, nil
+}
+
+func apiBaseUrl(apiUrl string) string {
+	return strings.TrimSuffix(apiUrl, "/") + "/" + apiVersionPath
+}
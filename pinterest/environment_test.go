@@ -0,0 +1,28 @@
+package pinterest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApiUrl(t *testing.T) {
+	url, err := ApiUrl("", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://api-sandbox.pinterest.com", url)
+
+	url, err = ApiUrl(EnvironmentProduction, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://api.pinterest.com", url)
+
+	url, err = ApiUrl(EnvironmentProduction, "http://localhost:8080/")
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost:8080", url)
+
+	_, err = ApiUrl("staging", "")
+	assert.EqualError(t, err, "unknown environment staging, use sandbox or production")
+
+	client := NewClient("token")
+	assert.Equal(t, "https://api-sandbox.pinterest.com/v5/", client.baseUrl)
+	assert.Equal(t, "https://api.pinterest.com/v5/", NewClient("token", WithApiUrl("https://api.pinterest.com")).baseUrl)
+}
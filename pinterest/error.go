@@ -3,27 +3,95 @@ package pinterest
 import (
 	"encoding/json"
 	"errors"
-	"io"
+	"fmt"
 	"net/http"
 )
 
+// ErrNotFound is matched by errors of requests that Pinterest answered with 404.
+var ErrNotFound = errors.New("not found")
+
+// APIError is returned for responses with an unexpected status code. Code and
+// Message are the error code and message of Pinterest, if the response had
+// them.
+type APIError struct {
+	StatusCode int
+	Code       int
+	Message    string
+	RequestId  string
+}
+
 type errorResponse struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
 }
 
-func handleWrongStatuscode(res *http.Response) (errorResponse, error) {
+func newAPIError(res *http.Response, bodyBytes []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: res.StatusCode,
+		RequestId:  res.Header.Get("X-Request-Id"),
+	}
+	if apiErr.RequestId == "" {
+		apiErr.RequestId = res.Header.Get("X-Pinterest-Rid")
+	}
+
 	errorResponse := errorResponse{}
+	if err := json.Unmarshal(bodyBytes, &errorResponse); err == nil {
+		apiErr.Code = errorResponse.Code
+		apiErr.Message = errorResponse.Message
+	} else {
+		apiErr.Message = string(bodyBytes)
+	}
+
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("unexpected status code %d. ErrorCode: %d ErrorMessage: %s", e.StatusCode, e.Code, e.Message)
+	if e.RequestId != "" {
+		msg += fmt.Sprintf(" RequestId: %s", e.RequestId)
+	}
+	return msg
+}
 
-	bytes, err := io.ReadAll(res.Body)
-	if err != nil {
-		return errorResponse, errors.New("unable to read response body while handleWrongStatuscode")
+// Is makes errors.Is match ErrNotFound and ErrRateLimited by status code.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	default:
+		return false
 	}
+}
 
-	err = json.Unmarshal(bytes, &errorResponse)
-	if err != nil {
-		return errorResponse, errors.New("unable to unmarshal response body while handleWrongStatuscode")
+func statusCodeOf(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
 	}
+	return 0
+}
+
+// IsUnauthorized reports whether Pinterest rejected the access token.
+func IsUnauthorized(err error) bool {
+	return statusCodeOf(err) == http.StatusUnauthorized
+}
+
+// IsRateLimited reports whether Pinterest or the client side rate limit
+// rejected the request.
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited)
+}
+
+// IsNotFound reports whether the requested resource doesn't exist.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
 
-	return errorResponse, nil
+// IsValidation reports whether Pinterest rejected the content of the request,
+// e.g. an invalid link or image. Sending it again won't help.
+func IsValidation(err error) bool {
+	status := statusCodeOf(err)
+	return status == http.StatusBadRequest || status == http.StatusUnprocessableEntity
 }
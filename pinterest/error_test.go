@@ -0,0 +1,47 @@
+package pinterest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIError(t *testing.T) {
+	status := http.StatusBadRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "abc")
+		w.WriteHeader(status)
+		fmt.Fprint(w, `{"code":1,"message":"Invalid link"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient("token", WithRetryPolicy(RetryPolicy{Disabled: true}))
+	client.baseUrl = server.URL + "/"
+
+	_, err := client.GetPin(context.Background(), "1")
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, &APIError{StatusCode: 400, Code: 1, Message: "Invalid link", RequestId: "abc"}, apiErr)
+	assert.True(t, IsValidation(err))
+	assert.False(t, IsNotFound(err))
+	assert.EqualError(t, err, "error executing request: unexpected status code 400. ErrorCode: 1 ErrorMessage: Invalid link RequestId: abc")
+
+	status = http.StatusUnauthorized
+	_, err = client.GetPin(context.Background(), "1")
+	assert.True(t, IsUnauthorized(err))
+
+	status = http.StatusNotFound
+	_, err = client.GetPin(context.Background(), "1")
+	assert.True(t, IsNotFound(err))
+	assert.True(t, errors.Is(err, ErrNotFound))
+
+	status = http.StatusTooManyRequests
+	_, err = client.GetPin(context.Background(), "1")
+	assert.True(t, IsRateLimited(err))
+	assert.False(t, IsValidation(err))
+}
@@ -0,0 +1,90 @@
+package pinterest
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"os"
+
+	_ "golang.org/x/image/bmp"
+	_ "golang.org/x/image/tiff"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	ContentTypePNG  = "image/png"
+	ContentTypeJPEG = "image/jpeg"
+
+	ConvertToPNG  = "png"
+	ConvertToJPEG = "jpeg"
+
+	sniffLength = 512
+	jpegQuality = 90
+)
+
+// ErrUnsupportedImage is returned for images Pinterest doesn't accept when no
+// conversion is configured.
+type ErrUnsupportedImage struct {
+	ImgPath     string
+	ContentType string
+}
+
+func (e ErrUnsupportedImage) Error() string {
+	return fmt.Sprintf("image %s has unsupported content type %s. Pinterest accepts %s and %s", e.ImgPath, e.ContentType, ContentTypePNG, ContentTypeJPEG)
+}
+
+func supportedContentType(contentType string) bool {
+	return contentType == ContentTypePNG || contentType == ContentTypeJPEG
+}
+
+// detectContentType sniffs the content type of the image from its first bytes.
+func detectContentType(imgPath string) (string, error) {
+	f, err := os.Open(imgPath)
+	if err != nil {
+		return "", fmt.Errorf("unable to open image: %w", err)
+	}
+	defer f.Close()
+
+	header := make([]byte, sniffLength)
+	n, err := io.ReadFull(f, header)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", fmt.Errorf("unable to read image: %w", err)
+	}
+
+	return http.DetectContentType(header[:n]), nil
+}
+
+// convertImage decodes the image and encodes it as PNG or JPEG.
+func convertImage(imgPath string, convertTo string) ([]byte, string, error) {
+	f, err := os.Open(imgPath)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to open image: %w", err)
+	}
+	defer f.Close()
+
+	return convertImageFrom(f, imgPath, convertTo)
+}
+
+func convertImageFrom(r io.Reader, name string, convertTo string) ([]byte, string, error) {
+	img, _, err := image.Decode(r)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to decode image %s: %w", name, err)
+	}
+
+	var buf bytes.Buffer
+	switch convertTo {
+	case ConvertToPNG:
+		err = png.Encode(&buf, img)
+		return buf.Bytes(), ContentTypePNG, err
+	case ConvertToJPEG:
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality})
+		return buf.Bytes(), ContentTypeJPEG, err
+	default:
+		return nil, "", fmt.Errorf("unknown image conversion %s", convertTo)
+	}
+}
@@ -0,0 +1,147 @@
+package pinterest
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// maxImageSize is the largest image Pinterest accepts.
+const maxImageSize = 20 << 20
+
+// imageDataMarker prefixes the placeholders of image files in marshaled
+// request bodies. It's random so no user content can be mistaken for it.
+var imageDataMarker = newImageDataMarker()
+
+func newImageDataMarker() string {
+	nonce := make([]byte, 8)
+	if _, err := rand.Read(nonce); err != nil {
+		panic(err)
+	}
+	return "pin-creator-image-" + hex.EncodeToString(nonce) + ":"
+}
+
+// imageData is the base64 encoded content of an image. Images backed by a file
+// are only read and encoded while the request body is sent, so they are never
+// held in memory as a whole.
+type imageData struct {
+	path string
+	data []byte
+}
+
+// fileImageData returns the image data of a file after checking that Pinterest
+// accepts its size.
+func fileImageData(imgPath string) (*imageData, error) {
+	info, err := os.Stat(imgPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read image: %w", err)
+	}
+	if info.Size() > maxImageSize {
+		return nil, fmt.Errorf("image %s is larger than %d bytes", imgPath, maxImageSize)
+	}
+
+	return &imageData{path: imgPath}, nil
+}
+
+func (d *imageData) MarshalJSON() ([]byte, error) {
+	if d.path != "" {
+		return json.Marshal(imageDataMarker + hex.EncodeToString([]byte(d.path)))
+	}
+	return json.Marshal(base64.StdEncoding.EncodeToString(d.data))
+}
+
+// bodySegment is either literal JSON or an image file streamed as base64.
+type bodySegment struct {
+	literal []byte
+	path    string
+	size    int64
+}
+
+// splitRequestBody cuts a marshaled request body at the image file
+// placeholders. It returns no segments if the body has no placeholders.
+func splitRequestBody(body []byte) ([]bodySegment, int64, error) {
+	marker := []byte(`"` + imageDataMarker)
+
+	var segments []bodySegment
+	var length int64
+	for {
+		i := bytes.Index(body, marker)
+		if i < 0 {
+			break
+		}
+
+		end := bytes.IndexByte(body[i+len(marker):], '"')
+		if end < 0 {
+			return nil, 0, fmt.Errorf("unterminated image placeholder in request body")
+		}
+		path, err := hex.DecodeString(string(body[i+len(marker) : i+len(marker)+end]))
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid image placeholder in request body: %v", err)
+		}
+
+		info, err := os.Stat(string(path))
+		if err != nil {
+			return nil, 0, fmt.Errorf("unable to read image: %w", err)
+		}
+
+		// keep the quotes around the placeholder as literals
+		segments = append(segments,
+			bodySegment{literal: body[:i+1]},
+			bodySegment{path: string(path), size: info.Size()},
+		)
+		length += int64(i+1) + int64(base64.StdEncoding.EncodedLen(int(info.Size())))
+		body = body[i+len(marker)+end:]
+	}
+
+	if segments == nil {
+		return nil, 0, nil
+	}
+
+	segments = append(segments, bodySegment{literal: body})
+	return segments, length + int64(len(body)), nil
+}
+
+// streamRequestBody returns a reader that writes the segments, encoding the
+// image files on the fly.
+func streamRequestBody(segments []bodySegment) io.ReadCloser {
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeRequestBody(pw, segments))
+	}()
+	return pr
+}
+
+func writeRequestBody(w io.Writer, segments []bodySegment) error {
+	for _, segment := range segments {
+		if segment.path == "" {
+			if _, err := w.Write(segment.literal); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := writeBase64File(w, segment.path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeBase64File(w io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("unable to open image: %w", err)
+	}
+	defer f.Close()
+
+	encoder := base64.NewEncoder(base64.StdEncoding, w)
+	if _, err := io.Copy(encoder, f); err != nil {
+		return fmt.Errorf("unable to read image: %w", err)
+	}
+	return encoder.Close()
+}
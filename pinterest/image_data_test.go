@@ -0,0 +1,71 @@
+package pinterest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateRequestStreamsImages(t *testing.T) {
+	imgPath := filepath.Join(t.TempDir(), "image.png")
+	content := []byte("not really a png, but streamed all the same")
+	assert.NoError(t, os.WriteFile(imgPath, content, 0o644))
+
+	data, err := fileImageData(imgPath)
+	assert.NoError(t, err)
+
+	client := NewClient("token")
+	req, err := client.createRequest("POST", "http://localhost/pins", createPinRequestBody{
+		Title: "title",
+		MediaSource: mediaSourceRequestBody{
+			SourceType: "multiple_image_base64",
+			Items: []carouselItemRequestBody{
+				{Data: data},
+				{Data: &imageData{data: []byte("in memory")}},
+				{Data: data},
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	body, err := io.ReadAll(req.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, req.ContentLength, int64(len(body)))
+
+	var decoded struct {
+		Title       string `json:"title"`
+		MediaSource struct {
+			Items []struct {
+				Data string `json:"data"`
+			} `json:"items"`
+		} `json:"media_source"`
+	}
+	assert.NoError(t, json.Unmarshal(body, &decoded))
+	assert.Equal(t, "title", decoded.Title)
+	assert.Equal(t, 3, len(decoded.MediaSource.Items))
+	assert.Equal(t, base64.StdEncoding.EncodeToString(content), decoded.MediaSource.Items[0].Data)
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("in memory")), decoded.MediaSource.Items[1].Data)
+	assert.Equal(t, decoded.MediaSource.Items[0].Data, decoded.MediaSource.Items[2].Data)
+
+	retryBody, err := req.GetBody()
+	assert.NoError(t, err)
+	retried, err := io.ReadAll(retryBody)
+	assert.NoError(t, err)
+	assert.Equal(t, body, retried)
+}
+
+func TestFileImageDataErrors(t *testing.T) {
+	_, err := fileImageData(filepath.Join(t.TempDir(), "missing.png"))
+	assert.Error(t, err)
+
+	imgPath := filepath.Join(t.TempDir(), "large.png")
+	assert.NoError(t, os.WriteFile(imgPath, nil, 0o644))
+	assert.NoError(t, os.Truncate(imgPath, maxImageSize+1))
+	_, err = fileImageData(imgPath)
+	assert.EqualError(t, err, "image "+imgPath+" is larger than 20971520 bytes")
+}
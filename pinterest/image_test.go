@@ -0,0 +1,94 @@
+package pinterest
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTestImage(t *testing.T, name string, encode func(f *os.File, img image.Image) error) string {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 6))
+	img.Set(1, 1, color.RGBA{R: 255, A: 255})
+
+	imgPath := filepath.Join(t.TempDir(), name)
+	f, err := os.Create(imgPath)
+	assert.NoError(t, err)
+	defer f.Close()
+
+	assert.NoError(t, encode(f, img))
+	return imgPath
+}
+
+func TestImageMediaSource(t *testing.T) {
+	jpegPath := writeTestImage(t, "image.png", func(f *os.File, img image.Image) error {
+		return jpeg.Encode(f, img, nil)
+	})
+	gifPath := writeTestImage(t, "image.gif", func(f *os.File, img image.Image) error {
+		return gif.Encode(f, img, nil)
+	})
+
+	mediaSource, err := imageMediaSource(jpegPath, "")
+	assert.NoError(t, err)
+	assert.Equal(t, ContentTypeJPEG, mediaSource.ContentType)
+	assert.Equal(t, &imageData{path: jpegPath}, mediaSource.Data)
+
+	_, err = imageMediaSource(gifPath, "")
+	assert.Equal(t, ErrUnsupportedImage{ImgPath: gifPath, ContentType: "image/gif"}, err)
+
+	mediaSource, err = imageMediaSource(gifPath, ConvertToPNG)
+	assert.NoError(t, err)
+	assert.Equal(t, ContentTypePNG, mediaSource.ContentType)
+
+	img, format, err := image.Decode(bytes.NewReader(mediaSource.Data.data))
+	assert.NoError(t, err)
+	assert.Equal(t, "png", format)
+	assert.Equal(t, image.Rect(0, 0, 4, 6), img.Bounds())
+
+	_, err = imageMediaSource(filepath.Join(t.TempDir(), "missing.png"), "")
+	assert.Error(t, err)
+}
+
+func TestPinMediaSourceFromUrl(t *testing.T) {
+	gifPath := writeTestImage(t, "image.gif", func(f *os.File, img image.Image) error {
+		return gif.Encode(f, img, nil)
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("Authorization"))
+		if r.URL.Path != "/image.gif" {
+			http.NotFound(w, r)
+			return
+		}
+		http.ServeFile(w, r, gifPath)
+	}))
+	defer server.Close()
+
+	client := NewClient("token")
+	imgUrl := server.URL + "/image.gif"
+
+	mediaSource, err := client.pinMediaSource(context.Background(), PinData{ImgPath: imgUrl})
+	assert.NoError(t, err)
+	assert.Equal(t, mediaSourceRequestBody{SourceType: "image_url", Url: imgUrl}, mediaSource)
+
+	_, err = client.pinMediaSource(context.Background(), PinData{ImgPath: imgUrl, DownloadImageUrl: true})
+	assert.Equal(t, ErrUnsupportedImage{ImgPath: imgUrl, ContentType: "image/gif"}, err)
+
+	mediaSource, err = client.pinMediaSource(context.Background(), PinData{ImgPath: imgUrl, DownloadImageUrl: true, ConvertTo: ConvertToJPEG})
+	assert.NoError(t, err)
+	assert.Equal(t, "image_base64", mediaSource.SourceType)
+	assert.Equal(t, ContentTypeJPEG, mediaSource.ContentType)
+	assert.NotEmpty(t, mediaSource.Data)
+
+	_, err = client.pinMediaSource(context.Background(), PinData{ImgPath: server.URL + "/missing.gif", DownloadImageUrl: true})
+	assert.Contains(t, err.Error(), "unexpected status code 404")
+}
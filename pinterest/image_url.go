@@ -0,0 +1,91 @@
+package pinterest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// IsImageUrl reports whether the pin image is fetched from the web.
+func IsImageUrl(imgPath string) bool {
+	return strings.HasPrefix(imgPath, "http://") || strings.HasPrefix(imgPath, "https://")
+}
+
+// pinMediaSource builds the media source of the pin image. Local images are
+// uploaded, image URLs are fetched by Pinterest unless the pin asks for them
+// to be downloaded and uploaded. Videos go through the media upload.
+func (c *Client) pinMediaSource(ctx context.Context, pinData PinData) (mediaSourceRequestBody, error) {
+	if len(pinData.Items) > 0 {
+		return c.carouselMediaSource(ctx, pinData)
+	}
+
+	if IsVideo(pinData.ImgPath) {
+		return c.videoMediaSource(ctx, pinData)
+	}
+
+	if !IsImageUrl(pinData.ImgPath) {
+		return imageMediaSource(pinData.ImgPath, pinData.ConvertTo)
+	}
+
+	if !pinData.DownloadImageUrl {
+		return mediaSourceRequestBody{
+			SourceType: "image_url",
+			Url:        pinData.ImgPath,
+		}, nil
+	}
+
+	data, err := c.downloadImage(ctx, pinData.ImgPath)
+	if err != nil {
+		return mediaSourceRequestBody{}, err
+	}
+
+	contentType := http.DetectContentType(data)
+	if !supportedContentType(contentType) {
+		if pinData.ConvertTo == "" {
+			return mediaSourceRequestBody{}, ErrUnsupportedImage{ImgPath: pinData.ImgPath, ContentType: contentType}
+		}
+
+		data, contentType, err = convertImageFrom(bytes.NewReader(data), pinData.ImgPath, pinData.ConvertTo)
+		if err != nil {
+			return mediaSourceRequestBody{}, err
+		}
+	}
+
+	return mediaSourceRequestBody{
+		SourceType:  "image_base64",
+		ContentType: contentType,
+		Data:        &imageData{data: data},
+	}, nil
+}
+
+// downloadImage fetches the image without the access token of the client, the
+// image host isn't Pinterest.
+func (c *Client) downloadImage(ctx context.Context, imgUrl string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", imgUrl, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	resp, err := c.transferClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to download image %s: %w", imgUrl, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to download image %s: unexpected status code %d", imgUrl, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxImageSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("unable to download image %s: %w", imgUrl, err)
+	}
+	if len(data) > maxImageSize {
+		return nil, fmt.Errorf("image %s is larger than %d bytes", imgUrl, maxImageSize)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,236 @@
+package pinterest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	MediaStatusRegistered = "registered"
+	MediaStatusProcessing = "processing"
+	MediaStatusSucceeded  = "succeeded"
+	MediaStatusFailed     = "failed"
+
+	defaultMediaPollInterval = 5 * time.Second
+	defaultMediaTimeout      = 10 * time.Minute
+)
+
+var videoExtensions = map[string]bool{
+	".mp4": true,
+	".m4v": true,
+	".mov": true,
+}
+
+// UploadedMedia is a video registered with Pinterest. Pins can only be created from it
+// once its status is succeeded.
+type UploadedMedia struct {
+	MediaId   string `json:"media_id"`
+	MediaType string `json:"media_type"`
+	Status    string `json:"status"`
+}
+
+// mediaUpload is the registration of a media. The file has to be posted to
+// UploadUrl together with the signed UploadParameters.
+type mediaUpload struct {
+	MediaId          string            `json:"media_id"`
+	MediaType        string            `json:"media_type"`
+	UploadUrl        string            `json:"upload_url"`
+	UploadParameters map[string]string `json:"upload_parameters"`
+}
+
+type registerMediaRequestBody struct {
+	MediaType string `json:"media_type"`
+}
+
+// IsVideo reports whether the pin file is uploaded as video.
+func IsVideo(path string) bool {
+	return videoExtensions[strings.ToLower(filepath.Ext(path))]
+}
+
+// uploadVideo registers the video, uploads it and waits until Pinterest has
+// processed it.
+func (c *Client) uploadVideo(ctx context.Context, videoPath string) (*UploadedMedia, error) {
+	log := c.log(ctx)
+
+	upload, err := c.registerMedia(ctx, "video")
+	if err != nil {
+		return nil, err
+	}
+
+	log.V(1).Info(fmt.Sprintf("Uploading video %s as media %s", videoPath, upload.MediaId))
+	if err := c.uploadMedia(ctx, upload, videoPath); err != nil {
+		return nil, err
+	}
+
+	return c.waitForMedia(ctx, upload.MediaId)
+}
+
+func (c *Client) registerMedia(ctx context.Context, mediaType string) (*mediaUpload, error) {
+	url := fmt.Sprintf("%s%s", c.baseUrl, "media")
+
+	req, err := c.createRequest("POST", url, registerMediaRequestBody{MediaType: mediaType})
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	responseBody, err := c.executeRequest(ctx, req, 201)
+	if err != nil {
+		return nil, fmt.Errorf("error executing request: %w", err)
+	}
+
+	var upload mediaUpload
+	if err := json.Unmarshal(responseBody, &upload); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal response body: %v", err)
+	}
+
+	return &upload, nil
+}
+
+// uploadMedia streams the file as multipart form to the upload URL. The upload
+// URL isn't part of the API, so the request carries no access token.
+func (c *Client) uploadMedia(ctx context.Context, upload *mediaUpload, filePath string) error {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("unable to open media: %w", err)
+	}
+	defer f.Close()
+
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	go func() {
+		pw.CloseWithError(writeMediaForm(mw, upload.UploadParameters, filepath.Base(filePath), f))
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", upload.UploadUrl, pr)
+	if err != nil {
+		pr.Close()
+		return fmt.Errorf("error creating request: %v", err)
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+
+	res, err := c.transferClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to upload media: %v", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		body, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("unable to upload media: unexpected status code %d. Response: %s", res.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// writeMediaForm writes the upload parameters before the file, the upload
+// endpoint ignores fields that follow it.
+func writeMediaForm(mw *multipart.Writer, params map[string]string, fileName string, file io.Reader) error {
+	for key, value := range params {
+		if err := mw.WriteField(key, value); err != nil {
+			return err
+		}
+	}
+
+	part, err := mw.CreateFormFile("file", fileName)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return err
+	}
+
+	return mw.Close()
+}
+
+// GetMedia returns the media including its processing status.
+func (c *Client) GetMedia(ctx context.Context, mediaId string) (*UploadedMedia, error) {
+	url := fmt.Sprintf("%s%s/%s", c.baseUrl, "media", mediaId)
+
+	req, err := c.createRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	responseBody, err := c.executeRequest(ctx, req, 200)
+	if err != nil {
+		return nil, fmt.Errorf("error executing request: %w", err)
+	}
+
+	var media UploadedMedia
+	if err := json.Unmarshal(responseBody, &media); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal response body: %v", err)
+	}
+
+	return &media, nil
+}
+
+// waitForMedia polls the media status until processing succeeded, failed or
+// the media timeout is reached.
+func (c *Client) waitForMedia(ctx context.Context, mediaId string) (*UploadedMedia, error) {
+	log := c.log(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, c.mediaTimeout)
+	defer cancel()
+
+	for {
+		media, err := c.GetMedia(ctx, mediaId)
+		if err != nil {
+			return nil, err
+		}
+
+		switch media.Status {
+		case MediaStatusSucceeded:
+			return media, nil
+		case MediaStatusFailed:
+			return nil, fmt.Errorf("processing of media %s failed", mediaId)
+		}
+
+		log.V(2).Info(fmt.Sprintf("Media %s is %s", mediaId, media.Status))
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("media %s wasn't processed in time: %w", mediaId, ctx.Err())
+		case <-time.After(c.mediaPollInterval):
+		}
+	}
+}
+
+func (c *Client) videoMediaSource(ctx context.Context, pinData PinData) (mediaSourceRequestBody, error) {
+	if IsImageUrl(pinData.ImgPath) {
+		return mediaSourceRequestBody{}, fmt.Errorf("video %s has to be a local file", pinData.ImgPath)
+	}
+
+	mediaSource := mediaSourceRequestBody{
+		SourceType:             "video_id",
+		CoverImageKeyFrameTime: pinData.CoverKeyFrameTime,
+	}
+
+	switch {
+	case IsImageUrl(pinData.CoverImagePath):
+		mediaSource.CoverImageUrl = pinData.CoverImagePath
+		mediaSource.CoverImageKeyFrameTime = 0
+	case pinData.CoverImagePath != "":
+		cover, err := imageMediaSource(pinData.CoverImagePath, pinData.ConvertTo)
+		if err != nil {
+			return mediaSourceRequestBody{}, err
+		}
+		mediaSource.CoverImageContentType = cover.ContentType
+		mediaSource.CoverImageData = cover.Data
+		mediaSource.CoverImageKeyFrameTime = 0
+	}
+
+	media, err := c.uploadVideo(ctx, pinData.ImgPath)
+	if err != nil {
+		return mediaSourceRequestBody{}, err
+	}
+	mediaSource.MediaId = media.MediaId
+
+	return mediaSource, nil
+}
@@ -0,0 +1,130 @@
+package pinterest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"pin-creator/pinterest/cassette"
+	"pin-creator/pinterest/pinteresttest"
+)
+
+func TestCreateVideoPin(t *testing.T) {
+	videoPath := filepath.Join(t.TempDir(), "video.mp4")
+	assert.NoError(t, os.WriteFile(videoPath, []byte("video data"), 0o644))
+
+	statusRequests := 0
+	var uploaded string
+	var pinBody createPinRequestBody
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/media":
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"media_id":"12","media_type":"video","upload_url":"%s/upload","upload_parameters":{"key":"signed"}}`, server.URL)
+		case r.Method == "POST" && r.URL.Path == "/upload":
+			assert.Empty(t, r.Header.Get("Authorization"))
+			assert.Equal(t, "signed", r.FormValue("key"))
+			f, _, err := r.FormFile("file")
+			assert.NoError(t, err)
+			data, _ := io.ReadAll(f)
+			uploaded = string(data)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == "GET" && r.URL.Path == "/media/12":
+			statusRequests++
+			status := MediaStatusProcessing
+			if statusRequests > 1 {
+				status = MediaStatusSucceeded
+			}
+			fmt.Fprintf(w, `{"media_id":"12","media_type":"video","status":"%s"}`, status)
+		case r.Method == "POST" && r.URL.Path == "/pins":
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&pinBody))
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id":"99"}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("token")
+	client.baseUrl = server.URL + "/"
+	client.mediaPollInterval = time.Millisecond
+
+	pin, err := client.CreatePin(context.Background(), PinData{
+		BoardId:           "1",
+		ImgPath:           videoPath,
+		CoverKeyFrameTime: 3,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "99", pin.ID)
+	assert.Equal(t, "video data", uploaded)
+	assert.Equal(t, 2, statusRequests)
+	assert.Equal(t, mediaSourceRequestBody{
+		SourceType:             "video_id",
+		MediaId:                "12",
+		CoverImageKeyFrameTime: 3,
+	}, pinBody.MediaSource)
+}
+
+func TestRecordAndReplayVideoPin(t *testing.T) {
+	videoPath := filepath.Join(t.TempDir(), "video.mp4")
+	assert.NoError(t, os.WriteFile(videoPath, []byte("video data"), 0o644))
+	cassettePath := filepath.Join(t.TempDir(), "cassette.yaml")
+
+	fake := pinteresttest.NewServer()
+	boardId := fake.AddBoard("board", "")
+	client := NewClient(fake.Token(),
+		WithApiUrl(fake.URL),
+		WithMiddleware(cassette.NewRecorder(cassettePath).Middleware()),
+	)
+	client.mediaPollInterval = time.Millisecond
+
+	recorded, err := client.CreatePin(context.Background(), PinData{BoardId: boardId, ImgPath: videoPath})
+	assert.NoError(t, err)
+	fake.Close()
+
+	c, err := cassette.Load(cassettePath)
+	assert.NoError(t, err)
+	uploads := 0
+	for _, interaction := range c.Interactions {
+		if strings.Contains(interaction.Request.URL, "/upload/") {
+			uploads++
+			assert.Empty(t, interaction.Request.Headers.Get("Authorization"))
+			assert.Contains(t, interaction.Request.Body, "REDACTED")
+		}
+	}
+	assert.Equal(t, 1, uploads)
+
+	replayer, err := cassette.NewReplayer(cassettePath)
+	assert.NoError(t, err)
+	client = NewClient("", WithApiUrl(fake.URL), WithTransport(replayer))
+	client.mediaPollInterval = time.Millisecond
+
+	replayed, err := client.CreatePin(context.Background(), PinData{BoardId: boardId, ImgPath: videoPath})
+	assert.NoError(t, err)
+	assert.Equal(t, recorded.ID, replayed.ID)
+}
+
+func TestWaitForMediaFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"media_id":"12","media_type":"video","status":"failed"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient("token")
+	client.baseUrl = server.URL + "/"
+
+	_, err := client.waitForMedia(context.Background(), "12")
+	assert.EqualError(t, err, "processing of media 12 failed")
+}
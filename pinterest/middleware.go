@@ -0,0 +1,136 @@
+package pinterest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+const defaultUserAgent = "pin-creator"
+
+// Middleware wraps the transport of API requests, e.g. to add headers, log or
+// measure requests. The retry middleware and the rate limit are added around
+// the middlewares, so every attempt passes through them. Image downloads and
+// video uploads pass through the middlewares as well.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc turns a function into a http.RoundTripper.
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// TokenSource returns the access token for a request.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+type staticTokenSource string
+
+func (s staticTokenSource) Token(ctx context.Context) (string, error) {
+	return string(s), nil
+}
+
+// StaticTokenSource always returns the same token.
+func StaticTokenSource(token string) TokenSource {
+	return staticTokenSource(token)
+}
+
+// RequestMetric describes a finished API request.
+type RequestMetric struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Duration   time.Duration
+	Err        error
+}
+
+// transport chains the middlewares around the transport of the HTTP client.
+// From the outside in: retry, rate limit and timeout of each attempt, the
+// middlewares of the client and the authentication.
+func (c *Client) transport() http.RoundTripper {
+	transport := c.httpClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	transport = AuthMiddleware(c.tokenSource, c.userAgent)(transport)
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		transport = c.middlewares[i](transport)
+	}
+	transport = c.attemptMiddleware()(transport)
+	return c.retryMiddleware()(transport)
+}
+
+// transferTransport chains the middlewares of the client around the transport
+// of the HTTP client for transfers to hosts other than the API. They carry no
+// access token and aren't retried or rate limited.
+func (c *Client) transferTransport() http.RoundTripper {
+	transport := c.httpClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		transport = c.middlewares[i](transport)
+	}
+	return transport
+}
+
+// AuthMiddleware adds the access token of the token source and the user agent
+// to the requests. Every client adds it as innermost middleware.
+func AuthMiddleware(tokenSource TokenSource, userAgent string) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			token, err := tokenSource.Token(req.Context())
+			if err != nil {
+				return nil, fmt.Errorf("unable to get access token: %w", err)
+			}
+
+			req = req.Clone(req.Context())
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+			if userAgent != "" {
+				req.Header.Set("User-Agent", userAgent)
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// LoggingMiddleware logs every request with its status and duration.
+func LoggingMiddleware(log logr.Logger) Middleware {
+	return MetricsMiddleware(func(m RequestMetric) {
+		if m.Err != nil {
+			log.Error(m.Err, "Request failed", "method", m.Method, "path", m.Path, "duration", m.Duration.String())
+			return
+		}
+		log.V(1).Info("Request finished", "method", m.Method, "path", m.Path, "status", m.StatusCode, "duration", m.Duration.String())
+	})
+}
+
+// MetricsMiddleware reports the status and duration of every request.
+func MetricsMiddleware(observe func(RequestMetric)) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			res, err := next.RoundTrip(req)
+
+			m := RequestMetric{
+				Method:   req.Method,
+				Path:     req.URL.Path,
+				Duration: time.Since(start),
+				Err:      err,
+			}
+			if res != nil {
+				m.StatusCode = res.StatusCode
+			}
+			observe(m)
+
+			return res, err
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package pinterest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// ClientOption configures a client.
+type ClientOption func(*Client)
+
+// WithBaseUrl sets the URL the API paths are appended to, including the API
+// version, e.g. http://localhost:8080/v5/.
+func WithBaseUrl(baseUrl string) ClientOption {
+	return func(c *Client) {
+		c.baseUrl = baseUrl
+	}
+}
+
+// WithApiUrl sends the requests to the API host, as returned by ApiUrl.
+func WithApiUrl(apiUrl string) ClientOption {
+	return func(c *Client) {
+		c.baseUrl = apiBaseUrl(apiUrl)
+	}
+}
+
+// WithHTTPClient sets the HTTP client the client sends its requests with. Its
+// transport is wrapped by the middlewares of the client.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithTransport sets the transport of the HTTP client.
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(c *Client) {
+		httpClient := *c.httpClient
+		httpClient.Transport = transport
+		c.httpClient = &httpClient
+	}
+}
+
+// WithUserAgent sets the User-Agent header of API requests.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithTimeout limits every attempt of an API request. Contexts with an
+// earlier deadline still take precedence.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithLogger makes the client log to the logger instead of the logger of the
+// request context.
+func WithLogger(log logr.Logger) ClientOption {
+	return func(c *Client) {
+		c.logger = &log
+	}
+}
+
+// WithTokenSource makes the client ask the token source for the access token
+// of every request instead of using a fixed token.
+func WithTokenSource(tokenSource TokenSource) ClientOption {
+	return func(c *Client) {
+		c.tokenSource = tokenSource
+	}
+}
+
+// WithMiddleware adds middlewares around the transport of API requests. The
+// first middleware is the outermost one.
+func WithMiddleware(middlewares ...Middleware) ClientOption {
+	return func(c *Client) {
+		c.middlewares = append(c.middlewares, middlewares...)
+	}
+}
+
+// WithRateLimitPolicy replaces the default rate limit policy of the client.
+func WithRateLimitPolicy(policy RateLimitPolicy) ClientOption {
+	return func(c *Client) {
+		c.rateLimiter = newRateLimiter(policy)
+	}
+}
+
+// WithRetryPolicy replaces the default retry policy of the client.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
@@ -0,0 +1,88 @@
+package pinterest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type countingTokenSource struct {
+	calls int
+}
+
+func (s *countingTokenSource) Token(ctx context.Context) (string, error) {
+	s.calls++
+	return "token-" + string(rune('0'+s.calls)), nil
+}
+
+func TestClientOptions(t *testing.T) {
+	var received []*http.Request
+	transport := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		received = append(received, req)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader(`{"id":"1"}`)),
+		}, nil
+	})
+
+	var order []string
+	middleware := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+
+	var metrics []RequestMetric
+	tokens := &countingTokenSource{}
+	client := NewClient("",
+		WithBaseUrl("http://fake/v5/"),
+		WithTransport(transport),
+		WithUserAgent("my-service/1.0"),
+		WithTokenSource(tokens),
+		WithMiddleware(middleware("first"), middleware("second")),
+		WithMiddleware(MetricsMiddleware(func(m RequestMetric) {
+			metrics = append(metrics, m)
+		})),
+	)
+
+	_, err := client.GetPin(context.Background(), "1")
+	assert.NoError(t, err)
+	_, err = client.GetPin(context.Background(), "1")
+	assert.NoError(t, err)
+
+	assert.Equal(t, 2, len(received))
+	assert.Equal(t, "http://fake/v5/pins/1?pin_metrics=true", received[0].URL.String())
+	assert.Equal(t, "Bearer token-1", received[0].Header.Get("Authorization"))
+	assert.Equal(t, "Bearer token-2", received[1].Header.Get("Authorization"))
+	assert.Equal(t, "my-service/1.0", received[0].Header.Get("User-Agent"))
+	assert.Equal(t, []string{"first", "second", "first", "second"}, order)
+	assert.Equal(t, 2, len(metrics))
+	assert.Equal(t, "/v5/pins/1", metrics[0].Path)
+	assert.Equal(t, http.StatusOK, metrics[0].StatusCode)
+}
+
+func TestClientTimeout(t *testing.T) {
+	transport := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	})
+
+	client := NewClient("token",
+		WithTransport(transport),
+		WithTimeout(10*time.Millisecond),
+		WithRetryPolicy(RetryPolicy{Disabled: true}),
+	)
+
+	_, err := client.GetPin(context.Background(), "1")
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
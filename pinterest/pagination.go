@@ -0,0 +1,119 @@
+package pinterest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+const (
+	defaultPageSize = 25
+	maxPageSize     = 250
+)
+
+type listOptions struct {
+	pageSize int
+}
+
+// ListOption configures a list call.
+type ListOption func(*listOptions)
+
+// WithPageSize sets the number of items requested per page. Pinterest allows
+// up to 250 items per page.
+func WithPageSize(pageSize int) ListOption {
+	return func(o *listOptions) {
+		o.pageSize = pageSize
+	}
+}
+
+func newListOptions(opts []ListOption) listOptions {
+	o := listOptions{pageSize: defaultPageSize}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.pageSize <= 0 {
+		o.pageSize = defaultPageSize
+	}
+	if o.pageSize > maxPageSize {
+		o.pageSize = maxPageSize
+	}
+	return o
+}
+
+type pageResponseBody struct {
+	Items    []json.RawMessage `json:"items"`
+	Bookmark string            `json:"bookmark"`
+}
+
+// pager walks through the pages of a list endpoint by following the bookmark
+// of every page until Pinterest returns none. Only one page is held in memory.
+type pager struct {
+	client   *Client
+	url      string
+	query    url.Values
+	items    []json.RawMessage
+	bookmark string
+	started  bool
+	err      error
+}
+
+func (c *Client) newPager(path string, query url.Values, opts listOptions) *pager {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("page_size", strconv.Itoa(opts.pageSize))
+
+	return &pager{
+		client: c,
+		url:    fmt.Sprintf("%s%s", c.baseUrl, path),
+		query:  query,
+	}
+}
+
+// next returns the next raw item, fetching the following page when the current
+// one is exhausted. It returns false when all pages are read or an error
+// occurred.
+func (p *pager) next(ctx context.Context) (json.RawMessage, bool) {
+	for len(p.items) == 0 {
+		if p.err != nil || (p.started && p.bookmark == "") {
+			return nil, false
+		}
+		p.err = p.fetch(ctx)
+	}
+
+	item := p.items[0]
+	p.items = p.items[1:]
+	return item, true
+}
+
+func (p *pager) fetch(ctx context.Context) error {
+	query := url.Values{}
+	for key, values := range p.query {
+		query[key] = values
+	}
+	if p.bookmark != "" {
+		query.Set("bookmark", p.bookmark)
+	}
+
+	req, err := p.client.createRequest("GET", fmt.Sprintf("%s?%s", p.url, query.Encode()), nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+
+	responseBody, err := p.client.executeRequest(ctx, req, 200)
+	if err != nil {
+		return fmt.Errorf("error executing request: %w", err)
+	}
+
+	var page pageResponseBody
+	if err := json.Unmarshal(responseBody, &page); err != nil {
+		return fmt.Errorf("unable to unmarshal response body: %v", err)
+	}
+
+	p.started = true
+	p.items = page.Items
+	p.bookmark = page.Bookmark
+	return nil
+}
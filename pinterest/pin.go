@@ -53,3 +53,21 @@ type MetricsData struct {
 	Reaction     int `json:"reaction,omitempty"`
 	Comment      int `json:"comment,omitempty"`
 }
+
+// AllTime sums the all time metrics of the pin.
+func (m *PinMetrics) AllTime() MetricsData {
+	total := MetricsData{}
+	if m == nil {
+		return total
+	}
+
+	for _, data := range m.PinMetrics {
+		total.PinClick += data.AllTime.PinClick
+		total.Impression += data.AllTime.Impression
+		total.Clickthrough += data.AllTime.Clickthrough
+		total.Reaction += data.AllTime.Reaction
+		total.Comment += data.AllTime.Comment
+	}
+
+	return total
+}
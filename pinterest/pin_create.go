@@ -4,14 +4,30 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-
-	"pin-creator/internal/logger"
 )
 
 type mediaSourceRequestBody struct {
-	SourceType  string `json:"source_type"`
-	ContentType string `json:"content_type"`
-	Data        string `json:"data"`
+	SourceType  string     `json:"source_type"`
+	ContentType string     `json:"content_type,omitempty"`
+	Data        *imageData `json:"data,omitempty"`
+	Url         string     `json:"url,omitempty"`
+
+	MediaId                string     `json:"media_id,omitempty"`
+	CoverImageUrl          string     `json:"cover_image_url,omitempty"`
+	CoverImageContentType  string     `json:"cover_image_content_type,omitempty"`
+	CoverImageData         *imageData `json:"cover_image_data,omitempty"`
+	CoverImageKeyFrameTime int        `json:"cover_image_key_frame_time,omitempty"`
+
+	Items []carouselItemRequestBody `json:"items,omitempty"`
+}
+
+type carouselItemRequestBody struct {
+	Title       string     `json:"title,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Link        string     `json:"link,omitempty"`
+	ContentType string     `json:"content_type,omitempty"`
+	Data        *imageData `json:"data,omitempty"`
+	Url         string     `json:"url,omitempty"`
 }
 
 type createPinRequestBody struct {
@@ -24,42 +40,53 @@ type createPinRequestBody struct {
 	MediaSource    mediaSourceRequestBody `json:"media_source"`
 }
 
-func (c *Client) CreatePin(ctx context.Context, pinData PinData) error {
+func (c *Client) CreatePin(ctx context.Context, pinData PinData) (*Pin, error) {
+	title, description := pinData.Title, pinData.Description
+	if len(pinData.Variants) > 0 {
+		if pinData.Variant < 0 || pinData.Variant >= len(pinData.Variants) {
+			return nil, fmt.Errorf("variant %d out of range, pin has %d variants", pinData.Variant, len(pinData.Variants))
+		}
+		title = pinData.Variants[pinData.Variant].Title
+		description = pinData.Variants[pinData.Variant].Description
+	}
+
+	mediaSource, err := c.pinMediaSource(ctx, pinData)
+	if err != nil {
+		return nil, err
+	}
+
 	createPinRequestBody := createPinRequestBody{
-		Link:        pinData.Link,
-		Title:       pinData.Title,
-		Description: pinData.Description,
-		AltText:     pinData.AltText,
-		BoardId:     pinData.BoardId,
-		MediaSource: mediaSourceRequestBody{
-			SourceType:  "image_base64",
-			ContentType: "image/png",
-			Data:        toBase64(pinData.ImgPath),
-		},
+		Link:           pinData.Link,
+		Title:          title,
+		Description:    description,
+		AltText:        pinData.AltText,
+		BoardId:        pinData.BoardId,
+		BoardSectionId: pinData.BoardSectionId,
+		MediaSource:    mediaSource,
 	}
 
 	return c.doCreatePin(ctx, createPinRequestBody)
 }
 
-func (c *Client) doCreatePin(ctx context.Context, body createPinRequestBody) error {
-	log := logger.FromContext(ctx)
+func (c *Client) doCreatePin(ctx context.Context, body createPinRequestBody) (*Pin, error) {
+	log := c.log(ctx)
 	url := fmt.Sprintf("%s%s", c.baseUrl, "pins")
 
 	req, err := c.createRequest("POST", url, body)
 	if err != nil {
-		return fmt.Errorf("error creating request: %v", err)
+		return nil, fmt.Errorf("error creating request: %v", err)
 	}
 
 	responseBody, err := c.executeRequest(ctx, req, 201)
 	if err != nil {
-		return fmt.Errorf("error executing request: %v", err)
+		return nil, fmt.Errorf("error executing request: %w", err)
 	}
 
-	var response interface{}
-	if err := json.Unmarshal(responseBody, &response); err != nil {
-		return fmt.Errorf("unable to decode response body: %v", err)
+	var pin Pin
+	if err := json.Unmarshal(responseBody, &pin); err != nil {
+		return nil, fmt.Errorf("unable to decode response body: %v", err)
 	}
 
 	log.V(2).Info(fmt.Sprintf("Pin created successfully. Response: %s", string(responseBody)))
-	return nil
+	return &pin, nil
 }
@@ -0,0 +1,22 @@
+package pinterest
+
+import (
+	"context"
+	"fmt"
+)
+
+func (c *Client) DeletePin(ctx context.Context, pinId string) error {
+	url := fmt.Sprintf("%s%s/%s", c.baseUrl, "pins", pinId)
+
+	req, err := c.createRequest("DELETE", url, nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+
+	_, err = c.executeRequest(ctx, req, 204)
+	if err != nil {
+		return fmt.Errorf("error executing request: %w", err)
+	}
+
+	return nil
+}
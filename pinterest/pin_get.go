@@ -0,0 +1,30 @@
+package pinterest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// GetPin returns the pin including its metrics. Metrics are only available for
+// pins owned by the authenticated user.
+func (c *Client) GetPin(ctx context.Context, pinId string) (*Pin, error) {
+	url := fmt.Sprintf("%s%s/%s?pin_metrics=true", c.baseUrl, "pins", pinId)
+
+	req, err := c.createRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	responseBody, err := c.executeRequest(ctx, req, 200)
+	if err != nil {
+		return nil, fmt.Errorf("error executing request: %w", err)
+	}
+
+	var pin Pin
+	if err := json.Unmarshal(responseBody, &pin); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal response body: %v", err)
+	}
+
+	return &pin, nil
+}
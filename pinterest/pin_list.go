@@ -0,0 +1,74 @@
+package pinterest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// PinIterator streams pins page by page. See BoardIterator for usage.
+type PinIterator struct {
+	ctx   context.Context
+	pager *pager
+	pin   Pin
+	err   error
+}
+
+// IteratePins streams the pins of the board, or of the whole account if
+// boardId is empty.
+func (c *Client) IteratePins(ctx context.Context, boardId string, opts ...ListOption) *PinIterator {
+	path := "pins"
+	if boardId != "" {
+		path = fmt.Sprintf("boards/%s/pins", boardId)
+	}
+
+	return &PinIterator{
+		ctx:   ctx,
+		pager: c.newPager(path, nil, newListOptions(opts)),
+	}
+}
+
+func (it *PinIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	item, ok := it.pager.next(it.ctx)
+	if !ok {
+		it.err = it.pager.err
+		return false
+	}
+
+	var pin Pin
+	if err := json.Unmarshal(item, &pin); err != nil {
+		it.err = fmt.Errorf("unable to unmarshal pin: %v", err)
+		return false
+	}
+
+	it.pin = pin
+	return true
+}
+
+func (it *PinIterator) Pin() Pin {
+	return it.pin
+}
+
+func (it *PinIterator) Err() error {
+	return it.err
+}
+
+// ListPins returns all pins of the board, or of the whole account if boardId is
+// empty, following the bookmark of every page.
+func (c *Client) ListPins(ctx context.Context, boardId string, opts ...ListOption) ([]Pin, error) {
+	pins := []Pin{}
+
+	it := c.IteratePins(ctx, boardId, opts...)
+	for it.Next() {
+		pins = append(pins, it.Pin())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return pins, nil
+}
@@ -1,7 +1,12 @@
 package pinterest
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -85,3 +90,43 @@ func TestPinUnmarshal(t *testing.T) {
 	assert.Empty(t, pin.ProductTags)
 	assert.Equal(t, "Second Video", pin.Title)
 }
+
+func TestGetUpdateAndDeletePin(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, fmt.Sprintf("%s %s %s", r.Method, r.URL.RequestURI(), body))
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"id":"42","title":"Pin","pin_metrics":{"all_time":{"impression":7}}}`)
+		case "PATCH":
+			fmt.Fprint(w, `{"id":"42","title":"New title","board_id":"2"}`)
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("token")
+	client.baseUrl = server.URL + "/"
+	ctx := context.Background()
+
+	pin, err := client.GetPin(ctx, "42")
+	assert.NoError(t, err)
+	assert.Equal(t, "Pin", pin.Title)
+
+	title := "New title"
+	boardId := "2"
+	pin, err = client.UpdatePin(ctx, "42", PinUpdate{Title: &title, BoardId: &boardId})
+	assert.NoError(t, err)
+	assert.Equal(t, "New title", pin.Title)
+	assert.Equal(t, "2", pin.BoardID)
+
+	assert.NoError(t, client.DeletePin(ctx, "42"))
+
+	assert.Equal(t, []string{
+		"GET /pins/42?pin_metrics=true ",
+		`PATCH /pins/42 {"title":"New title","board_id":"2"}`,
+		"DELETE /pins/42 ",
+	}, requests)
+}
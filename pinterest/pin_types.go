@@ -1,10 +1,55 @@
 package pinterest
 
 type PinData struct {
-	BoardId     string
+	BoardId        string
+	BoardSectionId string
+	// ImgPath is the local path or URL of the pin image. Local .mp4, .m4v and
+	// .mov files are uploaded as video.
 	ImgPath     string
 	Link        string
 	Title       string
 	Description string
 	AltText     string
+	Variants    []PinVariant
+	Variant     int
+	// ConvertTo is the format (png or jpeg) images Pinterest doesn't accept
+	// are converted to. Such images are rejected if it's empty.
+	ConvertTo string
+	// DownloadImageUrl makes the client download an ImgPath that is an
+	// http(s) URL and upload it like a local image instead of letting
+	// Pinterest fetch it. Needed when the URL isn't publicly reachable.
+	DownloadImageUrl bool
+	// CoverImagePath is the local path or URL of the cover image of a video
+	// pin. Without it the frame at CoverKeyFrameTime seconds is the cover.
+	CoverImagePath    string
+	CoverKeyFrameTime int
+	// Items turn the pin into a carousel of 2 to 5 images. ImgPath is ignored
+	// then.
+	Items []PinItem
+}
+
+// PinItem is one image of a carousel pin.
+type PinItem struct {
+	ImgPath     string
+	Title       string
+	Description string
+	Link        string
+}
+
+// PinVariant is an alternative title and description for a pin. When PinData
+// has variants, the one at index Variant replaces Title and Description.
+type PinVariant struct {
+	Title       string
+	Description string
+}
+
+// PinUpdate holds the fields of a pin to change. Nil fields are left
+// untouched. Setting BoardId moves the pin to another board.
+type PinUpdate struct {
+	Title          *string `json:"title,omitempty"`
+	Description    *string `json:"description,omitempty"`
+	Link           *string `json:"link,omitempty"`
+	AltText        *string `json:"alt_text,omitempty"`
+	BoardId        *string `json:"board_id,omitempty"`
+	BoardSectionId *string `json:"board_section_id,omitempty"`
 }
@@ -0,0 +1,29 @@
+package pinterest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// UpdatePin changes the given fields of the pin and returns the updated pin.
+func (c *Client) UpdatePin(ctx context.Context, pinId string, update PinUpdate) (*Pin, error) {
+	url := fmt.Sprintf("%s%s/%s", c.baseUrl, "pins", pinId)
+
+	req, err := c.createRequest("PATCH", url, update)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	responseBody, err := c.executeRequest(ctx, req, 200)
+	if err != nil {
+		return nil, fmt.Errorf("error executing request: %w", err)
+	}
+
+	var pin Pin
+	if err := json.Unmarshal(responseBody, &pin); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal response body: %v", err)
+	}
+
+	return &pin, nil
+}
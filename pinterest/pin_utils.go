@@ -1,15 +1,39 @@
 package pinterest
 
-import (
-	"encoding/base64"
-	"os"
-)
+// imageMediaSource builds the media source of a local image. Images in formats
+// Pinterest doesn't accept are converted if convertTo is set and rejected
+// otherwise.
+func imageMediaSource(imgPath string, convertTo string) (mediaSourceRequestBody, error) {
+	data, err := fileImageData(imgPath)
+	if err != nil {
+		return mediaSourceRequestBody{}, err
+	}
+
+	contentType, err := detectContentType(imgPath)
+	if err != nil {
+		return mediaSourceRequestBody{}, err
+	}
+
+	if supportedContentType(contentType) {
+		return mediaSourceRequestBody{
+			SourceType:  "image_base64",
+			ContentType: contentType,
+			Data:        data,
+		}, nil
+	}
+
+	if convertTo == "" {
+		return mediaSourceRequestBody{}, ErrUnsupportedImage{ImgPath: imgPath, ContentType: contentType}
+	}
 
-func toBase64(imgPath string) string {
-	bytes, err := os.ReadFile(imgPath)
+	converted, contentType, err := convertImage(imgPath, convertTo)
 	if err != nil {
-		panic(err)
+		return mediaSourceRequestBody{}, err
 	}
 
-	return base64.StdEncoding.EncodeToString(bytes)
+	return mediaSourceRequestBody{
+		SourceType:  "image_base64",
+		ContentType: contentType,
+		Data:        &imageData{data: converted},
+	}, nil
 }
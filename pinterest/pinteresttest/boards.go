@@ -0,0 +1,306 @@
+package pinteresttest
+
+import (
+	"net/http"
+	"time"
+)
+
+// Username is the owner of all boards and pins of the fake.
+const Username = "fake-user"
+
+var privacies = map[string]bool{
+	"PUBLIC":    true,
+	"PROTECTED": true,
+	"SECRET":    true,
+}
+
+type Board struct {
+	Id          string
+	Name        string
+	Description string
+	Privacy     string
+	CreatedAt   time.Time
+}
+
+type Section struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type owner struct {
+	Username string `json:"username"`
+}
+
+type boardResponseBody struct {
+	Id            string `json:"id"`
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	Owner         owner  `json:"owner"`
+	Privacy       string `json:"privacy"`
+	PinCount      int    `json:"pin_count"`
+	FollowerCount int    `json:"follower_count"`
+	CreatedAt     string `json:"created_at"`
+}
+
+type boardRequestBody struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+	Privacy     *string `json:"privacy"`
+}
+
+type sectionRequestBody struct {
+	Name string `json:"name"`
+}
+
+// AddBoard adds a board and returns its id.
+func (s *Server) AddBoard(name string, privacy string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addBoard(name, "", privacy).Id
+}
+
+// AddSection adds a section to the board and returns its id.
+func (s *Server) AddSection(boardId string, name string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addSection(boardId, name).Id
+}
+
+// Boards returns a copy of the boards.
+func (s *Server) Boards() []Board {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	boards := make([]Board, 0, len(s.boards))
+	for _, board := range s.boards {
+		boards = append(boards, *board)
+	}
+	return boards
+}
+
+// Sections returns a copy of the sections of the board.
+func (s *Server) Sections(boardId string) []Section {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sections := make([]Section, 0, len(s.sections[boardId]))
+	for _, section := range s.sections[boardId] {
+		sections = append(sections, *section)
+	}
+	return sections
+}
+
+func (s *Server) addBoard(name string, description string, privacy string) *Board {
+	if privacy == "" {
+		privacy = "PUBLIC"
+	}
+
+	board := &Board{
+		Id:          s.newId(),
+		Name:        name,
+		Description: description,
+		Privacy:     privacy,
+		CreatedAt:   s.now().UTC(),
+	}
+	s.boards = append(s.boards, board)
+	return board
+}
+
+func (s *Server) addSection(boardId string, name string) *Section {
+	section := &Section{Id: s.newId(), Name: name}
+	s.sections[boardId] = append(s.sections[boardId], section)
+	return section
+}
+
+func (s *Server) findBoard(boardId string) (int, *Board) {
+	for i, board := range s.boards {
+		if board.Id == boardId {
+			return i, board
+		}
+	}
+	return -1, nil
+}
+
+func (s *Server) boardResponse(board *Board) boardResponseBody {
+	pinCount := 0
+	for _, pin := range s.pins {
+		if pin.BoardId == board.Id {
+			pinCount++
+		}
+	}
+
+	return boardResponseBody{
+		Id:          board.Id,
+		Name:        board.Name,
+		Description: board.Description,
+		Owner:       owner{Username: Username},
+		Privacy:     board.Privacy,
+		PinCount:    pinCount,
+		CreatedAt:   board.CreatedAt.Format(timeFormat),
+	}
+}
+
+// handleBoards serves boards, boards/{id}, boards/{id}/sections,
+// boards/{id}/sections/{id} and boards/{id}/pins.
+func (s *Server) handleBoards(w http.ResponseWriter, r *http.Request, segments []string) {
+	switch {
+	case len(segments) == 0 && r.Method == "GET":
+		items := []interface{}{}
+		for _, board := range s.boards {
+			items = append(items, s.boardResponse(board))
+		}
+		writePage(w, r, items)
+		return
+	case len(segments) == 0 && r.Method == "POST":
+		s.createBoard(w, r)
+		return
+	case len(segments) == 0:
+		writeError(w, http.StatusMethodNotAllowed, 0, "method not allowed")
+		return
+	}
+
+	i, board := s.findBoard(segments[0])
+	if board == nil {
+		writeError(w, http.StatusNotFound, 40, "Board not found.")
+		return
+	}
+
+	switch {
+	case len(segments) == 1 && r.Method == "GET":
+		writeJSON(w, http.StatusOK, s.boardResponse(board))
+	case len(segments) == 1 && r.Method == "PATCH":
+		s.updateBoard(w, r, board)
+	case len(segments) == 1 && r.Method == "DELETE":
+		s.boards = append(s.boards[:i], s.boards[i+1:]...)
+		delete(s.sections, board.Id)
+		s.deletePins(func(pin *Pin) bool { return pin.BoardId == board.Id })
+		w.WriteHeader(http.StatusNoContent)
+	case len(segments) >= 2 && segments[1] == "sections":
+		s.handleSections(w, r, board, segments[2:])
+	case len(segments) == 2 && segments[1] == "pins" && r.Method == "GET":
+		items := []interface{}{}
+		for _, pin := range s.pins {
+			if pin.BoardId == board.Id {
+				items = append(items, pinResponse(pin, false))
+			}
+		}
+		writePage(w, r, items)
+	default:
+		writeError(w, http.StatusNotFound, 0, "unknown path")
+	}
+}
+
+func (s *Server) createBoard(w http.ResponseWriter, r *http.Request) {
+	var body boardRequestBody
+	if !readJSON(w, r, &body) {
+		return
+	}
+
+	if body.Name == nil || *body.Name == "" {
+		writeError(w, http.StatusBadRequest, 1, "Board name is required.")
+		return
+	}
+	for _, board := range s.boards {
+		if board.Name == *body.Name {
+			writeError(w, http.StatusConflict, 58, "Board with that name already exists.")
+			return
+		}
+	}
+
+	var description, privacy string
+	if body.Description != nil {
+		description = *body.Description
+	}
+	if body.Privacy != nil {
+		privacy = *body.Privacy
+	}
+	if privacy != "" && !privacies[privacy] {
+		writeError(w, http.StatusBadRequest, 1, "Invalid privacy.")
+		return
+	}
+
+	board := s.addBoard(*body.Name, description, privacy)
+	writeJSON(w, http.StatusCreated, s.boardResponse(board))
+}
+
+func (s *Server) updateBoard(w http.ResponseWriter, r *http.Request, board *Board) {
+	var body boardRequestBody
+	if !readJSON(w, r, &body) {
+		return
+	}
+
+	if body.Privacy != nil && !privacies[*body.Privacy] {
+		writeError(w, http.StatusBadRequest, 1, "Invalid privacy.")
+		return
+	}
+
+	if body.Name != nil {
+		board.Name = *body.Name
+	}
+	if body.Description != nil {
+		board.Description = *body.Description
+	}
+	if body.Privacy != nil {
+		board.Privacy = *body.Privacy
+	}
+	writeJSON(w, http.StatusOK, s.boardResponse(board))
+}
+
+func (s *Server) handleSections(w http.ResponseWriter, r *http.Request, board *Board, segments []string) {
+	sections := s.sections[board.Id]
+
+	switch {
+	case len(segments) == 0 && r.Method == "GET":
+		items := []interface{}{}
+		for _, section := range sections {
+			items = append(items, section)
+		}
+		writePage(w, r, items)
+		return
+	case len(segments) == 0 && r.Method == "POST":
+		var body sectionRequestBody
+		if !readJSON(w, r, &body) {
+			return
+		}
+		if body.Name == "" {
+			writeError(w, http.StatusBadRequest, 1, "Section name is required.")
+			return
+		}
+		writeJSON(w, http.StatusCreated, s.addSection(board.Id, body.Name))
+		return
+	case len(segments) != 1:
+		writeError(w, http.StatusNotFound, 0, "unknown path")
+		return
+	}
+
+	for i, section := range sections {
+		if section.Id != segments[0] {
+			continue
+		}
+
+		switch r.Method {
+		case "PATCH":
+			var body sectionRequestBody
+			if !readJSON(w, r, &body) {
+				return
+			}
+			section.Name = body.Name
+			writeJSON(w, http.StatusOK, section)
+		case "DELETE":
+			s.sections[board.Id] = append(sections[:i], sections[i+1:]...)
+			for _, pin := range s.pins {
+				if pin.BoardSectionId == section.Id {
+					pin.BoardSectionId = ""
+				}
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, 0, "method not allowed")
+		}
+		return
+	}
+
+	writeError(w, http.StatusNotFound, 0, "Board section not found.")
+}
@@ -0,0 +1,89 @@
+package pinteresttest
+
+import (
+	"net/http"
+)
+
+const (
+	MediaStatusRegistered = "registered"
+	MediaStatusSucceeded  = "succeeded"
+)
+
+// Media is a registered video. Its status becomes succeeded once the file was
+// uploaded.
+type Media struct {
+	MediaId   string `json:"media_id"`
+	MediaType string `json:"media_type"`
+	Status    string `json:"status"`
+	// Size is the size of the uploaded file.
+	Size int64 `json:"-"`
+}
+
+type mediaUploadResponseBody struct {
+	MediaId          string            `json:"media_id"`
+	MediaType        string            `json:"media_type"`
+	UploadUrl        string            `json:"upload_url"`
+	UploadParameters map[string]string `json:"upload_parameters"`
+}
+
+// handleMedia serves media and media/{id}.
+func (s *Server) handleMedia(w http.ResponseWriter, r *http.Request, segments []string) {
+	switch {
+	case len(segments) == 0 && r.Method == "POST":
+		var body struct {
+			MediaType string `json:"media_type"`
+		}
+		if !readJSON(w, r, &body) {
+			return
+		}
+		if body.MediaType != "video" {
+			writeError(w, http.StatusBadRequest, 1, "media_type must be video.")
+			return
+		}
+
+		media := &Media{MediaId: s.newId(), MediaType: body.MediaType, Status: MediaStatusRegistered}
+		s.media[media.MediaId] = media
+		writeJSON(w, http.StatusCreated, mediaUploadResponseBody{
+			MediaId:          media.MediaId,
+			MediaType:        media.MediaType,
+			UploadUrl:        s.URL + uploadPrefix + media.MediaId,
+			UploadParameters: map[string]string{"key": media.MediaId},
+		})
+	case len(segments) == 1 && r.Method == "GET":
+		media, ok := s.media[segments[0]]
+		if !ok {
+			writeError(w, http.StatusNotFound, 0, "Media not found.")
+			return
+		}
+		writeJSON(w, http.StatusOK, media)
+	default:
+		writeError(w, http.StatusNotFound, 0, "unknown path")
+	}
+}
+
+// handleUpload accepts the multipart upload of a registered media. Like the
+// real upload URL, it doesn't require an access token.
+func (s *Server) handleUpload(w http.ResponseWriter, r *http.Request, mediaId string) {
+	media, ok := s.media[mediaId]
+	if !ok || r.Method != "POST" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, header, err := r.FormFile("file")
+	if err != nil || r.FormValue("key") != mediaId {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	file.Close()
+
+	media.Size = header.Size
+	media.Status = MediaStatusSucceeded
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,58 @@
+package pinteresttest
+
+import (
+	"net/http"
+)
+
+// AuthCode is the authorization code the token exchange accepts.
+const AuthCode = "fake-auth-code"
+
+type tokenResponseBody struct {
+	AccessToken           string `json:"access_token"`
+	RefreshToken          string `json:"refresh_token"`
+	ResponseType          string `json:"response_type"`
+	TokenType             string `json:"token_type"`
+	ExpiresIn             int    `json:"expires_in"`
+	RefreshTokenExpiresIn int    `json:"refresh_token_expires_in"`
+	Scope                 string `json:"scope"`
+}
+
+// Token returns the access token requests have to carry.
+func (s *Server) Token() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.token
+}
+
+// handleToken exchanges AuthCode for a new access token. The previous token
+// is no longer accepted afterwards, like a revoked token.
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		writeError(w, http.StatusMethodNotAllowed, 0, "method not allowed")
+		return
+	}
+	if _, _, ok := r.BasicAuth(); !ok && r.Header.Get("Authorization") == "" {
+		writeError(w, http.StatusUnauthorized, 2, "Authentication failed.")
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, 1, "Invalid form.")
+		return
+	}
+	if r.PostForm.Get("grant_type") != "authorization_code" || r.PostForm.Get("code") != AuthCode {
+		writeError(w, http.StatusBadRequest, 1, "Invalid authorization code.")
+		return
+	}
+
+	s.token = DefaultToken + "-" + s.newId()
+	writeJSON(w, http.StatusOK, tokenResponseBody{
+		AccessToken:           s.token,
+		RefreshToken:          "fake-refresh-token",
+		ResponseType:          "authorization_code",
+		TokenType:             "bearer",
+		ExpiresIn:             2592000,
+		RefreshTokenExpiresIn: 31536000,
+		Scope:                 "boards:read,boards:write,pins:read,pins:write",
+	})
+}
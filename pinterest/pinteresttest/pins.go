@@ -0,0 +1,286 @@
+package pinteresttest
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	maxTitleLength       = 100
+	maxDescriptionLength = 800
+	minCarouselItems     = 2
+	maxCarouselItems     = 5
+)
+
+// Pin is a pin created through the fake. MediaType is image, video or
+// multiple_images, SourceType is the source type of the create request.
+type Pin struct {
+	Id             string
+	BoardId        string
+	BoardSectionId string
+	Title          string
+	Description    string
+	Link           string
+	AltText        string
+	MediaType      string
+	SourceType     string
+	CreatedAt      time.Time
+}
+
+type mediaSourceRequestBody struct {
+	SourceType  string `json:"source_type"`
+	ContentType string `json:"content_type"`
+	Data        string `json:"data"`
+	Url         string `json:"url"`
+	MediaId     string `json:"media_id"`
+
+	Items []struct {
+		ContentType string `json:"content_type"`
+		Data        string `json:"data"`
+		Url         string `json:"url"`
+	} `json:"items"`
+}
+
+type pinRequestBody struct {
+	Link           *string                `json:"link"`
+	Title          *string                `json:"title"`
+	Description    *string                `json:"description"`
+	AltText        *string                `json:"alt_text"`
+	BoardId        *string                `json:"board_id"`
+	BoardSectionId *string                `json:"board_section_id"`
+	MediaSource    mediaSourceRequestBody `json:"media_source"`
+}
+
+type pinMetricsResponseBody struct {
+	PinMetrics []map[string]map[string]int `json:"pin_metrics"`
+}
+
+type pinResponseBody struct {
+	Id             string                  `json:"id"`
+	CreatedAt      string                  `json:"created_at"`
+	Link           string                  `json:"link"`
+	Title          string                  `json:"title"`
+	Description    string                  `json:"description"`
+	AltText        string                  `json:"alt_text"`
+	BoardId        string                  `json:"board_id"`
+	BoardSectionId *string                 `json:"board_section_id"`
+	BoardOwner     owner                   `json:"board_owner"`
+	IsOwner        bool                    `json:"is_owner"`
+	Media          map[string]string       `json:"media"`
+	PinMetrics     *pinMetricsResponseBody `json:"pin_metrics,omitempty"`
+}
+
+// Pins returns a copy of the pins.
+func (s *Server) Pins() []Pin {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pins := make([]Pin, 0, len(s.pins))
+	for _, pin := range s.pins {
+		pins = append(pins, *pin)
+	}
+	return pins
+}
+
+func pinResponse(pin *Pin, withMetrics bool) pinResponseBody {
+	body := pinResponseBody{
+		Id:          pin.Id,
+		CreatedAt:   pin.CreatedAt.Format(timeFormat),
+		Link:        pin.Link,
+		Title:       pin.Title,
+		Description: pin.Description,
+		AltText:     pin.AltText,
+		BoardId:     pin.BoardId,
+		BoardOwner:  owner{Username: Username},
+		IsOwner:     true,
+		Media:       map[string]string{"media_type": pin.MediaType},
+	}
+	if pin.BoardSectionId != "" {
+		sectionId := pin.BoardSectionId
+		body.BoardSectionId = &sectionId
+	}
+	if withMetrics {
+		zero := map[string]int{"pin_click": 0, "impression": 0, "clickthrough": 0}
+		body.PinMetrics = &pinMetricsResponseBody{
+			PinMetrics: []map[string]map[string]int{{"90d": zero, "all_time": zero}},
+		}
+	}
+	return body
+}
+
+func (s *Server) deletePins(match func(pin *Pin) bool) {
+	pins := s.pins[:0]
+	for _, pin := range s.pins {
+		if !match(pin) {
+			pins = append(pins, pin)
+		}
+	}
+	s.pins = pins
+}
+
+// handlePins serves pins and pins/{id}.
+func (s *Server) handlePins(w http.ResponseWriter, r *http.Request, segments []string) {
+	switch {
+	case len(segments) == 0 && r.Method == "GET":
+		items := []interface{}{}
+		for _, pin := range s.pins {
+			items = append(items, pinResponse(pin, false))
+		}
+		writePage(w, r, items)
+		return
+	case len(segments) == 0 && r.Method == "POST":
+		s.createPin(w, r)
+		return
+	case len(segments) != 1:
+		writeError(w, http.StatusNotFound, 0, "unknown path")
+		return
+	}
+
+	for i, pin := range s.pins {
+		if pin.Id != segments[0] {
+			continue
+		}
+
+		switch r.Method {
+		case "GET":
+			writeJSON(w, http.StatusOK, pinResponse(pin, r.URL.Query().Get("pin_metrics") == "true"))
+		case "PATCH":
+			s.updatePin(w, r, pin)
+		case "DELETE":
+			s.pins = append(s.pins[:i], s.pins[i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, 0, "method not allowed")
+		}
+		return
+	}
+
+	writeError(w, http.StatusNotFound, 50, "Pin not found.")
+}
+
+func (s *Server) createPin(w http.ResponseWriter, r *http.Request) {
+	var body pinRequestBody
+	if !readJSON(w, r, &body) {
+		return
+	}
+
+	pin := &Pin{Id: s.newId(), CreatedAt: s.now().UTC()}
+	if !s.applyPin(w, pin, body) {
+		return
+	}
+	if pin.BoardId == "" {
+		writeError(w, http.StatusBadRequest, 1, "board_id is required.")
+		return
+	}
+
+	mediaType, err := s.validateMediaSource(body.MediaSource)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, 1, err.Error())
+		return
+	}
+	pin.MediaType = mediaType
+	pin.SourceType = body.MediaSource.SourceType
+
+	s.pins = append(s.pins, pin)
+	writeJSON(w, http.StatusCreated, pinResponse(pin, false))
+}
+
+func (s *Server) updatePin(w http.ResponseWriter, r *http.Request, pin *Pin) {
+	var body pinRequestBody
+	if !readJSON(w, r, &body) {
+		return
+	}
+
+	updated := *pin
+	if !s.applyPin(w, &updated, body) {
+		return
+	}
+	*pin = updated
+	writeJSON(w, http.StatusOK, pinResponse(pin, false))
+}
+
+// applyPin validates the fields of the request and sets them on the pin. It
+// answers with an error and returns false if a field is invalid.
+func (s *Server) applyPin(w http.ResponseWriter, pin *Pin, body pinRequestBody) bool {
+	if body.Title != nil {
+		if len([]rune(*body.Title)) > maxTitleLength {
+			writeError(w, http.StatusBadRequest, 1, fmt.Sprintf("Title must be at most %d characters.", maxTitleLength))
+			return false
+		}
+		pin.Title = *body.Title
+	}
+	if body.Description != nil {
+		if len([]rune(*body.Description)) > maxDescriptionLength {
+			writeError(w, http.StatusBadRequest, 1, fmt.Sprintf("Description must be at most %d characters.", maxDescriptionLength))
+			return false
+		}
+		pin.Description = *body.Description
+	}
+	if body.Link != nil {
+		if *body.Link != "" && !strings.HasPrefix(*body.Link, "http://") && !strings.HasPrefix(*body.Link, "https://") {
+			writeError(w, http.StatusBadRequest, 1, "Invalid link.")
+			return false
+		}
+		pin.Link = *body.Link
+	}
+	if body.AltText != nil {
+		pin.AltText = *body.AltText
+	}
+	if body.BoardId != nil {
+		if _, board := s.findBoard(*body.BoardId); board == nil {
+			writeError(w, http.StatusNotFound, 40, "Board not found.")
+			return false
+		}
+		pin.BoardId = *body.BoardId
+		pin.BoardSectionId = ""
+	}
+	if body.BoardSectionId != nil && *body.BoardSectionId != "" {
+		found := false
+		for _, section := range s.sections[pin.BoardId] {
+			found = found || section.Id == *body.BoardSectionId
+		}
+		if !found {
+			writeError(w, http.StatusNotFound, 0, "Board section not found.")
+			return false
+		}
+		pin.BoardSectionId = *body.BoardSectionId
+	}
+	return true
+}
+
+// validateMediaSource checks that the media source has the fields of its
+// source type and returns the media type of the pin.
+func (s *Server) validateMediaSource(source mediaSourceRequestBody) (string, error) {
+	switch source.SourceType {
+	case "image_base64":
+		if source.Data == "" || source.ContentType == "" {
+			return "", fmt.Errorf("image_base64 requires data and content_type")
+		}
+		return "image", nil
+	case "image_url":
+		if source.Url == "" {
+			return "", fmt.Errorf("image_url requires url")
+		}
+		return "image", nil
+	case "video_id":
+		media, ok := s.media[source.MediaId]
+		if !ok || media.Status != MediaStatusSucceeded {
+			return "", fmt.Errorf("media %s is not uploaded", source.MediaId)
+		}
+		return "video", nil
+	case "multiple_image_base64", "multiple_image_urls":
+		if len(source.Items) < minCarouselItems || len(source.Items) > maxCarouselItems {
+			return "", fmt.Errorf("%s requires %d to %d items", source.SourceType, minCarouselItems, maxCarouselItems)
+		}
+		for _, item := range source.Items {
+			if item.Data == "" && item.Url == "" {
+				return "", fmt.Errorf("items require data or url")
+			}
+		}
+		return "multiple_images", nil
+	default:
+		return "", fmt.Errorf("unsupported source_type %s", source.SourceType)
+	}
+}
@@ -0,0 +1,277 @@
+// Package pinteresttest provides a fake Pinterest API for tests and offline
+// runs. The fake keeps boards, sections, pins and media in memory and answers
+// the endpoints the pinterest package uses. Failures and rate limits can be
+// injected to test error handling.
+//
+//	fake := pinteresttest.NewServer()
+//	defer fake.Close()
+//	client := pinterest.NewClient(fake.Token(), pinterest.WithApiUrl(fake.URL))
+package pinteresttest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultToken is the access token the server accepts from the start.
+	DefaultToken = "fake-access-token"
+
+	defaultPageSize = 25
+	maxPageSize     = 250
+	apiPrefix       = "/v5/"
+	uploadPrefix    = "/upload/"
+)
+
+// Failure makes the server answer matching requests with an error instead of
+// handling them. Empty Method and Path match every request, Path matches by
+// prefix below /v5/, e.g. "pins" or "boards/1/sections". Times is the number
+// of requests that fail, zero fails all of them.
+type Failure struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Code       int
+	Message    string
+	Times      int
+}
+
+// Server is a fake Pinterest API. The API is served below URL + "/v5/".
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	token     string
+	nextId    int
+	boards    []*Board
+	sections  map[string][]*Section
+	pins      []*Pin
+	media     map[string]*Media
+	failures  []*Failure
+	rateLimit int
+	buckets   map[string]*bucket
+	now       func() time.Time
+}
+
+type bucket struct {
+	remaining int
+	reset     time.Time
+}
+
+// NewServer starts a fake API without any boards or pins.
+func NewServer() *Server {
+	s := &Server{
+		token:    DefaultToken,
+		sections: map[string][]*Section{},
+		media:    map[string]*Media{},
+		buckets:  map[string]*bucket{},
+		now:      time.Now,
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Fail injects a failure. Failures are checked in the order they were added.
+func (s *Server) Fail(failure Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if failure.Message == "" {
+		failure.Message = http.StatusText(failure.StatusCode)
+	}
+	s.failures = append(s.failures, &failure)
+}
+
+// SetRateLimit limits the requests per minute of every category, like
+// pins_write or boards_read. Requests over the limit are answered with 429.
+// Zero removes the limit.
+func (s *Server) SetRateLimit(perMinute int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rateLimit = perMinute
+	s.buckets = map[string]*bucket{}
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if strings.HasPrefix(r.URL.Path, uploadPrefix) {
+		s.handleUpload(w, r, strings.TrimPrefix(r.URL.Path, uploadPrefix))
+		return
+	}
+
+	if !strings.HasPrefix(r.URL.Path, apiPrefix) {
+		writeError(w, http.StatusNotFound, 0, "unknown path")
+		return
+	}
+	path := strings.TrimPrefix(r.URL.Path, apiPrefix)
+
+	if path == "oauth/token" {
+		s.handleToken(w, r)
+		return
+	}
+
+	if r.Header.Get("Authorization") != "Bearer "+s.token {
+		writeError(w, http.StatusUnauthorized, 2, "Authentication failed.")
+		return
+	}
+
+	if s.limited(w, r, path) {
+		return
+	}
+
+	if failure := s.failure(r.Method, path); failure != nil {
+		writeError(w, failure.StatusCode, failure.Code, failure.Message)
+		return
+	}
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	switch segments[0] {
+	case "boards":
+		s.handleBoards(w, r, segments[1:])
+	case "pins":
+		s.handlePins(w, r, segments[1:])
+	case "media":
+		s.handleMedia(w, r, segments[1:])
+	default:
+		writeError(w, http.StatusNotFound, 0, "unknown path")
+	}
+}
+
+func (s *Server) failure(method string, path string) *Failure {
+	for i, failure := range s.failures {
+		if failure.Method != "" && failure.Method != method {
+			continue
+		}
+		if !strings.HasPrefix(path, failure.Path) {
+			continue
+		}
+
+		if failure.Times > 0 {
+			failure.Times--
+			if failure.Times == 0 {
+				s.failures = append(s.failures[:i], s.failures[i+1:]...)
+			}
+		}
+		return failure
+	}
+	return nil
+}
+
+// limited answers the request with 429 if the rate limit of its category is
+// exhausted. The rate limit headers are set on every response.
+func (s *Server) limited(w http.ResponseWriter, r *http.Request, path string) bool {
+	if s.rateLimit == 0 {
+		return false
+	}
+
+	category := path
+	if i := strings.Index(category, "/"); i >= 0 {
+		category = category[:i]
+	}
+	if r.Method == "GET" {
+		category += "_read"
+	} else {
+		category += "_write"
+	}
+
+	now := s.now()
+	b, ok := s.buckets[category]
+	if !ok || !now.Before(b.reset) {
+		b = &bucket{remaining: s.rateLimit, reset: now.Add(time.Minute)}
+		s.buckets[category] = b
+	}
+
+	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(s.rateLimit))
+	w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(b.reset.Unix(), 10))
+	if b.remaining == 0 {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("Retry-After", strconv.Itoa(int(b.reset.Sub(now).Seconds())+1))
+		writeError(w, http.StatusTooManyRequests, 8, "Too many requests.")
+		return true
+	}
+
+	b.remaining--
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(b.remaining))
+	return false
+}
+
+func (s *Server) newId() string {
+	s.nextId++
+	return strconv.Itoa(s.nextId)
+}
+
+type pageResponseBody struct {
+	Items    []interface{} `json:"items"`
+	Bookmark *string       `json:"bookmark"`
+}
+
+// writePage answers with the page of items selected by the page_size and
+// bookmark parameters. The bookmark is the offset of the next page.
+func writePage(w http.ResponseWriter, r *http.Request, items []interface{}) {
+	pageSize := defaultPageSize
+	if value := r.URL.Query().Get("page_size"); value != "" {
+		size, err := strconv.Atoi(value)
+		if err != nil || size < 1 || size > maxPageSize {
+			writeError(w, http.StatusBadRequest, 1, fmt.Sprintf("Invalid page_size %s.", value))
+			return
+		}
+		pageSize = size
+	}
+
+	offset := 0
+	if value := r.URL.Query().Get("bookmark"); value != "" {
+		o, err := strconv.Atoi(value)
+		if err != nil || o < 0 || o > len(items) {
+			writeError(w, http.StatusBadRequest, 1, fmt.Sprintf("Invalid bookmark %s.", value))
+			return
+		}
+		offset = o
+	}
+
+	page := pageResponseBody{Items: []interface{}{}}
+	end := offset + pageSize
+	if end > len(items) {
+		end = len(items)
+	}
+	page.Items = append(page.Items, items[offset:end]...)
+	if end < len(items) {
+		bookmark := strconv.Itoa(end)
+		page.Bookmark = &bookmark
+	}
+
+	writeJSON(w, http.StatusOK, page)
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, code int, message string) {
+	writeJSON(w, statusCode, struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}{code, message})
+}
+
+// readJSON decodes the request body and answers with 400 if it is invalid.
+func readJSON(w http.ResponseWriter, r *http.Request, body interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+		writeError(w, http.StatusBadRequest, 1, fmt.Sprintf("Invalid request body: %v", err))
+		return false
+	}
+	return true
+}
+
+// timeFormat is the format Pinterest uses for created_at.
+const timeFormat = "2006-01-02T15:04:05"
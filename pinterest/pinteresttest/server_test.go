@@ -0,0 +1,193 @@
+package pinteresttest
+
+import (
+	"context"
+	"image"
+	"image/png"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"pin-creator/pinterest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newClient(fake *Server) *pinterest.Client {
+	return pinterest.NewClient(fake.Token(),
+		pinterest.WithApiUrl(fake.URL),
+		pinterest.WithRetryPolicy(pinterest.RetryPolicy{Disabled: true}),
+	)
+}
+
+func writePng(t *testing.T, name string) string {
+	path := filepath.Join(t.TempDir(), name)
+	f, err := os.Create(path)
+	assert.NoError(t, err)
+	defer f.Close()
+	assert.NoError(t, png.Encode(f, image.NewRGBA(image.Rect(0, 0, 2, 2))))
+	return path
+}
+
+func TestBoardsAndSections(t *testing.T) {
+	fake := NewServer()
+	defer fake.Close()
+	client := newClient(fake)
+	ctx := context.Background()
+
+	for _, name := range []string{"first", "second", "third"} {
+		_, err := client.CreateBoard(ctx, pinterest.BoardData{Name: name, Privacy: pinterest.PrivacySecret})
+		assert.NoError(t, err)
+	}
+
+	boards, err := client.ListBoards(ctx, pinterest.WithPageSize(2))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(boards))
+	assert.Equal(t, "third", boards[2].Name)
+	assert.Equal(t, pinterest.PrivacySecret, boards[2].Privacy)
+	assert.Equal(t, Username, boards[2].Owner.Username)
+
+	_, err = client.CreateBoard(ctx, pinterest.BoardData{Name: "first"})
+	assert.Error(t, err)
+
+	name := "renamed"
+	board, err := client.UpdateBoard(ctx, boards[0].Id, pinterest.BoardUpdate{Name: &name})
+	assert.NoError(t, err)
+	assert.Equal(t, "renamed", board.Name)
+
+	section, err := client.CreateBoardSection(ctx, board.Id, "section")
+	assert.NoError(t, err)
+	sections, err := client.ListBoardSections(ctx, board.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, []pinterest.BoardSection{{Id: section.Id, Name: "section"}}, sections)
+
+	assert.NoError(t, client.DeleteBoardSection(ctx, board.Id, section.Id))
+	assert.Equal(t, []Section{}, fake.Sections(board.Id))
+
+	assert.NoError(t, client.DeleteBoard(ctx, board.Id))
+	_, err = client.GetBoard(ctx, board.Id)
+	assert.True(t, pinterest.IsNotFound(err))
+	assert.Equal(t, 2, len(fake.Boards()))
+}
+
+func TestPins(t *testing.T) {
+	fake := NewServer()
+	defer fake.Close()
+	client := newClient(fake)
+	ctx := context.Background()
+
+	boardId := fake.AddBoard("board", "")
+	sectionId := fake.AddSection(boardId, "section")
+
+	pin, err := client.CreatePin(ctx, pinterest.PinData{
+		BoardId:        boardId,
+		BoardSectionId: sectionId,
+		ImgPath:        writePng(t, "pin.png"),
+		Link:           "https://example.com",
+		Title:          "title",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "title", pin.Title)
+	assert.Equal(t, sectionId, *pin.BoardSectionID)
+	assert.Equal(t, "image_base64", fake.Pins()[0].SourceType)
+
+	_, err = client.CreatePin(ctx, pinterest.PinData{
+		BoardId: boardId,
+		ImgPath: "https://example.com/pin.png",
+		Title:   strings.Repeat("x", 101),
+	})
+	assert.True(t, pinterest.IsValidation(err))
+
+	title := "new title"
+	updated, err := client.UpdatePin(ctx, pin.ID, pinterest.PinUpdate{Title: &title})
+	assert.NoError(t, err)
+	assert.Equal(t, "new title", updated.Title)
+
+	got, err := client.GetPin(ctx, pin.ID)
+	assert.NoError(t, err)
+	assert.NotNil(t, got.PinMetrics)
+
+	pins, err := client.ListPins(ctx, boardId)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(pins))
+
+	assert.NoError(t, client.DeletePin(ctx, pin.ID))
+	assert.Equal(t, []Pin{}, fake.Pins())
+}
+
+func TestVideoAndCarouselPins(t *testing.T) {
+	fake := NewServer()
+	defer fake.Close()
+	client := newClient(fake)
+	ctx := context.Background()
+
+	boardId := fake.AddBoard("board", "")
+	videoPath := filepath.Join(t.TempDir(), "pin.mp4")
+	assert.NoError(t, os.WriteFile(videoPath, []byte("video"), 0o644))
+
+	pin, err := client.CreatePin(ctx, pinterest.PinData{BoardId: boardId, ImgPath: videoPath, CoverKeyFrameTime: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "video", pin.Media.MediaType)
+
+	pin, err = client.CreatePin(ctx, pinterest.PinData{
+		BoardId: boardId,
+		Items:   []pinterest.PinItem{{ImgPath: writePng(t, "1.png")}, {ImgPath: writePng(t, "2.png")}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "multiple_images", pin.Media.MediaType)
+}
+
+func TestInjectedFailures(t *testing.T) {
+	fake := NewServer()
+	defer fake.Close()
+	client := newClient(fake)
+	ctx := context.Background()
+
+	fake.Fail(Failure{Method: "GET", Path: "boards", StatusCode: http.StatusInternalServerError, Times: 1})
+	_, err := client.ListBoards(ctx)
+	assert.Error(t, err)
+	_, err = client.ListBoards(ctx)
+	assert.NoError(t, err)
+
+	fake.SetRateLimit(1)
+	client = pinterest.NewClient(fake.Token(),
+		pinterest.WithApiUrl(fake.URL),
+		pinterest.WithRateLimitPolicy(pinterest.RateLimitPolicy{FailFast: true}),
+	)
+	_, err = client.CreateBoard(ctx, pinterest.BoardData{Name: "first"})
+	assert.NoError(t, err)
+	_, err = client.CreateBoard(ctx, pinterest.BoardData{Name: "second"})
+	assert.True(t, pinterest.IsRateLimited(err))
+	assert.Equal(t, 0, client.RateLimits()[0].Remaining)
+
+	_, err = pinterest.NewClient("wrong", pinterest.WithApiUrl(fake.URL)).ListBoards(ctx)
+	assert.True(t, pinterest.IsUnauthorized(err))
+}
+
+func TestTokenExchange(t *testing.T) {
+	fake := NewServer()
+	defer fake.Close()
+
+	exchange := func(code string) int {
+		form := url.Values{"grant_type": {"authorization_code"}, "code": {code}}
+		req, _ := http.NewRequest("POST", fake.URL+"/v5/oauth/token", strings.NewReader(form.Encode()))
+		req.SetBasicAuth("app", "secret")
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		res, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		res.Body.Close()
+		return res.StatusCode
+	}
+
+	assert.Equal(t, http.StatusBadRequest, exchange("wrong"))
+	assert.Equal(t, http.StatusOK, exchange(AuthCode))
+	assert.NotEqual(t, DefaultToken, fake.Token())
+
+	_, err := pinterest.NewClient(DefaultToken, pinterest.WithApiUrl(fake.URL)).ListBoards(context.Background())
+	assert.True(t, pinterest.IsUnauthorized(err))
+	_, err = newClient(fake).ListBoards(context.Background())
+	assert.NoError(t, err)
+}
@@ -0,0 +1,229 @@
+package pinterest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultReadPerMinute  = 1000
+	defaultWritePerMinute = 100
+	// defaultRetryAfter is used if Pinterest answers 429 without Retry-After.
+	defaultRetryAfter = time.Minute
+)
+
+// ErrRateLimited is matched by errors of requests that were rejected with 429
+// or not sent because the client side rate limit was exhausted.
+var ErrRateLimited = errors.New("rate limited")
+
+// RateLimitPolicy configures the client side rate limit. PerMinute limits the
+// requests per endpoint category, like pins_write or boards_read, falling back
+// to the read and write keys. Requests wait for the limit unless FailFast is
+// set or the wait is longer than MaxWait.
+type RateLimitPolicy struct {
+	PerMinute map[string]int
+	FailFast  bool
+	MaxWait   time.Duration
+}
+
+// RateLimit is the rate limit Pinterest reported for an endpoint category.
+type RateLimit struct {
+	Category  string
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// RateLimits returns the rate limits Pinterest reported so far.
+func (c *Client) RateLimits() []RateLimit {
+	return c.rateLimiter.reported()
+}
+
+// rateLimitCategory groups requests by their first path segment and whether
+// they read or write, e.g. pins_write.
+func (c *Client) rateLimitCategory(req *http.Request) string {
+	path := strings.TrimPrefix(req.URL.String(), c.baseUrl)
+	if i := strings.IndexAny(path, "/?"); i >= 0 {
+		path = path[:i]
+	}
+	return path + "_" + rateLimitKind(req.Method)
+}
+
+func rateLimitKind(method string) string {
+	if method == "GET" {
+		return "read"
+	}
+	return "write"
+}
+
+type tokenBucket struct {
+	capacity     float64
+	tokens       float64
+	perSecond    float64
+	last         time.Time
+	blockedUntil time.Time
+}
+
+// take removes a token from the bucket. If the bucket is empty or blocked, it
+// returns how long to wait before trying again.
+func (b *tokenBucket) take(now time.Time) time.Duration {
+	if now.Before(b.blockedUntil) {
+		return b.blockedUntil.Sub(now)
+	}
+
+	b.tokens += now.Sub(b.last).Seconds() * b.perSecond
+	if b.tokens > b.capacity {
+		b.tokens = b.capacity
+	}
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.perSecond * float64(time.Second))
+}
+
+type rateLimiter struct {
+	mu      sync.Mutex
+	policy  RateLimitPolicy
+	buckets map[string]*tokenBucket
+	limits  map[string]RateLimit
+	now     func() time.Time
+}
+
+func newRateLimiter(policy RateLimitPolicy) *rateLimiter {
+	return &rateLimiter{
+		policy:  policy,
+		buckets: map[string]*tokenBucket{},
+		limits:  map[string]RateLimit{},
+		now:     time.Now,
+	}
+}
+
+func (l *rateLimiter) bucket(category string) *tokenBucket {
+	b, ok := l.buckets[category]
+	if ok {
+		return b
+	}
+
+	kind := category[strings.LastIndex(category, "_")+1:]
+	perMinute, ok := l.policy.PerMinute[category]
+	if !ok {
+		perMinute, ok = l.policy.PerMinute[kind]
+	}
+	if !ok {
+		perMinute = defaultWritePerMinute
+		if kind == "read" {
+			perMinute = defaultReadPerMinute
+		}
+	}
+
+	b = &tokenBucket{
+		capacity:  float64(perMinute),
+		tokens:    float64(perMinute),
+		perSecond: float64(perMinute) / 60,
+		last:      l.now(),
+	}
+	l.buckets[category] = b
+	return b
+}
+
+// wait blocks until a request of the category may be sent.
+func (l *rateLimiter) wait(ctx context.Context, category string) error {
+	for {
+		l.mu.Lock()
+		d := l.bucket(category).take(l.now())
+		l.mu.Unlock()
+
+		if d <= 0 {
+			return nil
+		}
+		if l.policy.FailFast || (l.policy.MaxWait > 0 && d > l.policy.MaxWait) {
+			return fmt.Errorf("%w: no %s requests left for %s", ErrRateLimited, category, d.Truncate(time.Second))
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(d):
+		}
+	}
+}
+
+// update records the rate limit headers of the response. Requests of the
+// category are held back until the reset if no requests are left, or for the
+// Retry-After duration if Pinterest answered 429.
+func (l *rateLimiter) update(category string, res *http.Response) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b := l.bucket(category)
+
+	limit, errLimit := strconv.Atoi(res.Header.Get("X-RateLimit-Limit"))
+	remaining, errRemaining := strconv.Atoi(res.Header.Get("X-RateLimit-Remaining"))
+	if errLimit == nil && errRemaining == nil {
+		reset := parseRateLimitReset(res.Header.Get("X-RateLimit-Reset"), now)
+		l.limits[category] = RateLimit{Category: category, Limit: limit, Remaining: remaining, Reset: reset}
+
+		if float64(remaining) < b.tokens {
+			b.tokens = float64(remaining)
+		}
+		if remaining == 0 && reset.After(b.blockedUntil) {
+			b.blockedUntil = reset
+		}
+	}
+
+	if res.StatusCode != http.StatusTooManyRequests {
+		return
+	}
+
+	wait := retryAfter(res)
+	b.tokens = 0
+	if now.Add(wait).After(b.blockedUntil) {
+		b.blockedUntil = now.Add(wait)
+	}
+}
+
+// retryAfter returns the Retry-After duration of a 429 response.
+func retryAfter(res *http.Response) time.Duration {
+	if seconds, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	return defaultRetryAfter
+}
+
+func (l *rateLimiter) reported() []RateLimit {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	limits := make([]RateLimit, 0, len(l.limits))
+	for _, limit := range l.limits {
+		limits = append(limits, limit)
+	}
+	sort.Slice(limits, func(i, j int) bool {
+		return limits[i].Category < limits[j].Category
+	})
+	return limits
+}
+
+// parseRateLimitReset reads the reset header as seconds from now or, for
+// large values, as unix timestamp.
+func parseRateLimitReset(value string, now time.Time) time.Time {
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	if seconds > 1e9 {
+		return time.Unix(seconds, 0)
+	}
+	return now.Add(time.Duration(seconds) * time.Second)
+}
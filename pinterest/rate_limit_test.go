@@ -0,0 +1,85 @@
+package pinterest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitHeadersAndRetryAfter(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "41")
+		w.Header().Set("X-RateLimit-Reset", "30")
+		if r.Method == "DELETE" {
+			w.Header().Set("Retry-After", "120")
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, `{"code":8,"message":"too many requests"}`)
+			return
+		}
+		fmt.Fprint(w, `{"id":"1"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient("token",
+		WithRateLimitPolicy(RateLimitPolicy{FailFast: true}),
+		WithRetryPolicy(RetryPolicy{Disabled: true}),
+	)
+	client.baseUrl = server.URL + "/"
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	client.rateLimiter.now = func() time.Time { return now }
+
+	_, err := client.GetPin(context.Background(), "1")
+	assert.NoError(t, err)
+	assert.Equal(t, []RateLimit{{
+		Category:  "pins_read",
+		Limit:     100,
+		Remaining: 41,
+		Reset:     now.Add(30 * time.Second),
+	}}, client.RateLimits())
+
+	err = client.DeletePin(context.Background(), "1")
+	assert.True(t, errors.Is(err, ErrRateLimited))
+
+	// blocked until Retry-After, fails fast without sending
+	err = client.DeletePin(context.Background(), "1")
+	assert.True(t, errors.Is(err, ErrRateLimited))
+	assert.Equal(t, 2, requests)
+
+	// reads are limited separately
+	_, err = client.GetPin(context.Background(), "1")
+	assert.NoError(t, err)
+
+	now = now.Add(121 * time.Second)
+	err = client.DeletePin(context.Background(), "1")
+	assert.True(t, errors.Is(err, ErrRateLimited))
+	assert.Equal(t, 4, requests)
+}
+
+func TestTokenBucket(t *testing.T) {
+	l := newRateLimiter(RateLimitPolicy{PerMinute: map[string]int{"write": 2}})
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+
+	b := l.bucket("pins_write")
+	assert.Equal(t, time.Duration(0), b.take(now))
+	assert.Equal(t, time.Duration(0), b.take(now))
+	assert.Equal(t, 30*time.Second, b.take(now))
+
+	now = now.Add(30 * time.Second)
+	assert.Equal(t, time.Duration(0), b.take(now))
+
+	assert.Equal(t, float64(defaultReadPerMinute), l.bucket("pins_read").capacity)
+
+	l.policy.MaxWait = time.Second
+	err := l.wait(context.Background(), "pins_write")
+	assert.True(t, errors.Is(err, ErrRateLimited))
+}
@@ -0,0 +1,176 @@
+package pinterest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/go-logr/logr"
+)
+
+const (
+	defaultRequestRetries         = 3
+	defaultRetryInitialInterval   = 500 * time.Millisecond
+	defaultRetryMaxInterval       = 10 * time.Second
+	defaultRetryRandomization     = 0.5
+	defaultRequestRetryMultiplier = 2
+)
+
+// RetryPolicy configures how often failed requests are retried. Idempotent
+// requests are retried on network errors and 5xx responses, all requests are
+// retried if they couldn't be sent or were rejected with 429. Zero values use
+// the defaults.
+type RetryPolicy struct {
+	Disabled        bool
+	MaxRetries      int
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+}
+
+// backOff returns the exponential backoff with jitter of a request.
+func (p RetryPolicy) backOff() backoff.BackOff {
+	if p.Disabled {
+		return &backoff.StopBackOff{}
+	}
+
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = defaultRetryInitialInterval
+	if p.InitialInterval > 0 {
+		b.InitialInterval = p.InitialInterval
+	}
+	b.MaxInterval = defaultRetryMaxInterval
+	if p.MaxInterval > 0 {
+		b.MaxInterval = p.MaxInterval
+	}
+	b.RandomizationFactor = defaultRetryRandomization
+	b.Multiplier = defaultRequestRetryMultiplier
+	// the number of retries and the context limit the retries
+	b.MaxElapsedTime = 0
+
+	maxRetries := defaultRequestRetries
+	if p.MaxRetries > 0 {
+		maxRetries = p.MaxRetries
+	}
+	return backoff.WithMaxRetries(b, uint64(maxRetries))
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	default:
+		return false
+	}
+}
+
+// retryableStatus reports whether a request that got the status may be sent
+// again.
+func retryableStatus(method string, status int) bool {
+	if status == http.StatusTooManyRequests {
+		return true
+	}
+	return status >= 500 && isIdempotent(method)
+}
+
+// retryableError reports whether a request that failed with the error may be
+// sent again. Requests that failed to connect never reached Pinterest.
+func retryableError(method string, err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	return isIdempotent(method)
+}
+
+// retryStatus fails an attempt whose response may be retried. The response is
+// returned if no retry is left.
+type retryStatus struct {
+	res *http.Response
+}
+
+func (e *retryStatus) Error() string {
+	return fmt.Sprintf("unexpected status code %d", e.res.StatusCode)
+}
+
+// RetryMiddleware retries failed requests with the backoff of the policy.
+// Every client adds it as outermost middleware with the policy of
+// WithRetryPolicy. To place it elsewhere in the chain, disable that policy and
+// add RetryMiddleware with WithMiddleware.
+func RetryMiddleware(policy RetryPolicy, log logr.Logger) Middleware {
+	return retryMiddleware(
+		func() RetryPolicy { return policy },
+		func(ctx context.Context) logr.Logger { return log },
+	)
+}
+
+func (c *Client) retryMiddleware() Middleware {
+	return retryMiddleware(func() RetryPolicy { return c.retryPolicy }, c.log)
+}
+
+func retryMiddleware(policy func() RetryPolicy, logFor func(ctx context.Context) logr.Logger) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			ctx := req.Context()
+
+			attempt := 0
+			var res *http.Response
+			operation := func() error {
+				attempt++
+				attemptReq := req
+				if attempt > 1 {
+					if res != nil {
+						res.Body.Close()
+						res = nil
+					}
+					if req.GetBody != nil {
+						body, err := req.GetBody()
+						if err != nil {
+							return backoff.Permanent(fmt.Errorf("unable to reset request body: %v", err))
+						}
+						attemptReq = req.Clone(ctx)
+						attemptReq.Body = body
+					}
+				}
+
+				var err error
+				res, err = next.RoundTrip(attemptReq)
+				if err != nil {
+					if ctx.Err() != nil || errors.Is(err, ErrRateLimited) || !retryableError(req.Method, err) {
+						return backoff.Permanent(err)
+					}
+					return err
+				}
+				if ctx.Err() == nil && retryableStatus(req.Method, res.StatusCode) {
+					return &retryStatus{res: res}
+				}
+				return nil
+			}
+			notify := func(err error, wait time.Duration) {
+				logFor(ctx).Info(fmt.Sprintf("Request %s %s failed. Retrying in %s", req.Method, req.URL.Path, wait.Truncate(time.Millisecond)), "attempt", attempt, "error", err.Error())
+			}
+
+			// requests without a rewindable body can only be sent once
+			b := policy().backOff()
+			if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+				b = &backoff.StopBackOff{}
+			}
+
+			err := backoff.RetryNotify(operation, backoff.WithContext(b, ctx), notify)
+			var status *retryStatus
+			if errors.As(err, &status) {
+				return status.res, nil
+			}
+			if err != nil {
+				if res != nil {
+					res.Body.Close()
+				}
+				return nil, err
+			}
+			return res, nil
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package pinterest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteRequestRetries(t *testing.T) {
+	requests := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.Method]++
+		if r.Method == "GET" && requests["GET"] < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `{"code":1,"message":"unavailable"}`)
+			return
+		}
+		if r.Method == "POST" {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"code":1,"message":"internal error"}`)
+			return
+		}
+		fmt.Fprint(w, `{"id":"1"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient("token", WithRetryPolicy(RetryPolicy{
+		InitialInterval: time.Millisecond,
+		MaxInterval:     time.Millisecond,
+	}))
+	client.baseUrl = server.URL + "/"
+
+	pin, err := client.GetPin(context.Background(), "1")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", pin.ID)
+	assert.Equal(t, 3, requests["GET"])
+
+	_, err = client.CreateBoard(context.Background(), BoardData{Name: "board"})
+	assert.Error(t, err)
+	assert.Equal(t, 1, requests["POST"])
+
+	client.retryPolicy = RetryPolicy{Disabled: true}
+	requests["GET"] = 0
+	_, err = client.GetPin(context.Background(), "1")
+	assert.Error(t, err)
+	assert.Equal(t, 1, requests["GET"])
+}
+
+func TestRetryableError(t *testing.T) {
+	client := NewClient("token", WithRetryPolicy(RetryPolicy{
+		InitialInterval: time.Millisecond,
+		MaxInterval:     time.Millisecond,
+		MaxRetries:      2,
+	}))
+	// nothing listens on the port, so the request can't be sent and is retried
+	// even though it isn't idempotent
+	client.baseUrl = "http://127.0.0.1:1/"
+
+	_, err := client.CreateBoard(context.Background(), BoardData{Name: "board"})
+	assert.Error(t, err)
+	assert.True(t, retryableError("POST", err))
+}
+
+func TestLostResponseIsOnlyRetriedForIdempotentRequests(t *testing.T) {
+	requests := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.Method]++
+		// the connection is closed before the announced body is complete
+		w.Header().Set("Content-Length", "100")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id":`)
+	}))
+	defer server.Close()
+
+	client := NewClient("token", WithRetryPolicy(RetryPolicy{
+		InitialInterval: time.Millisecond,
+		MaxInterval:     time.Millisecond,
+		MaxRetries:      2,
+	}))
+	client.baseUrl = server.URL + "/"
+
+	_, err := client.CreateBoard(context.Background(), BoardData{Name: "board"})
+	assert.Error(t, err)
+	assert.Equal(t, 1, requests["POST"])
+
+	_, err = client.GetPin(context.Background(), "1")
+	assert.Error(t, err)
+	assert.Equal(t, 3, requests["GET"])
+}
+
+func TestRetryMiddlewareInChain(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		fmt.Fprint(w, `{"id":"1"}`)
+	}))
+	defer server.Close()
+
+	var statuses []int
+	policy := RetryPolicy{InitialInterval: time.Millisecond, MaxInterval: time.Millisecond}
+	client := NewClient("token",
+		WithRetryPolicy(RetryPolicy{Disabled: true}),
+		WithMiddleware(
+			MetricsMiddleware(func(m RequestMetric) { statuses = append(statuses, m.StatusCode) }),
+			RetryMiddleware(policy, logr.Discard()),
+		),
+	)
+	client.baseUrl = server.URL + "/"
+
+	pin, err := client.GetPin(context.Background(), "1")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", pin.ID)
+	// the metrics middleware is outside of the retry middleware and sees one request
+	assert.Equal(t, []int{200}, statuses)
+	assert.Equal(t, 3, requests)
+}
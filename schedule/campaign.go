@@ -0,0 +1,165 @@
+package schedule
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	CampaignActive    = "active"
+	CampaignPaused    = "paused"
+	CampaignScheduled = "scheduled"
+	CampaignEnded     = "ended"
+)
+
+// Campaign groups schedule rows. Rows of a campaign are only selected while the
+// campaign runs and isn't paused. A start or end date without a time of day
+// covers the whole day.
+type Campaign struct {
+	Name         string            `yaml:"name"`
+	Start        time.Time         `yaml:"start"`
+	End          time.Time         `yaml:"end"`
+	DefaultBoard string            `yaml:"default_board"`
+	UTM          map[string]string `yaml:"utm"`
+	Paused       bool              `yaml:"paused"`
+}
+
+type campaignFile struct {
+	Campaigns []Campaign `yaml:"campaigns"`
+}
+
+// CampaignProgress counts the rows of a campaign by their status.
+type CampaignProgress struct {
+	Campaign Campaign
+	Posted   int
+	Pending  int
+	Failed   int
+}
+
+func ReadCampaigns(filePath string) ([]Campaign, error) {
+	yamlFile, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read campaign file. Error: %s", err.Error())
+	}
+
+	f := campaignFile{}
+	err = yaml.Unmarshal(yamlFile, &f)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse campaign file. Error: %s", err.Error())
+	}
+
+	names := map[string]bool{}
+	for _, c := range f.Campaigns {
+		if c.Name == "" {
+			return nil, fmt.Errorf("campaign without name in %s", filePath)
+		}
+		if names[c.Name] {
+			return nil, fmt.Errorf("campaign %s is defined twice in %s", c.Name, filePath)
+		}
+		if !c.End.IsZero() && c.windowEnd().Before(c.Start) {
+			return nil, fmt.Errorf("campaign %s ends before it starts", c.Name)
+		}
+		names[c.Name] = true
+	}
+
+	return f.Campaigns, nil
+}
+
+// State returns whether the campaign is paused, hasn't started yet, has ended or
+// is active at the given time.
+func (c Campaign) State(now time.Time) string {
+	switch {
+	case c.Paused:
+		return CampaignPaused
+	case !c.Start.IsZero() && now.Before(c.Start):
+		return CampaignScheduled
+	case !c.End.IsZero() && !now.Before(c.windowEnd()):
+		return CampaignEnded
+	default:
+		return CampaignActive
+	}
+}
+
+func (c Campaign) windowEnd() time.Time {
+	if c.End.Equal(c.End.Truncate(24 * time.Hour)) {
+		return c.End.Add(24 * time.Hour)
+	}
+	return c.End
+}
+
+// apply fills in the campaign defaults for a row.
+func (c Campaign) apply(row *NextPinData) error {
+	if row.BoardName == "" {
+		row.BoardName = c.DefaultBoard
+	}
+
+	if len(c.UTM) == 0 || row.Link == "" {
+		return nil
+	}
+
+	link, err := url.Parse(row.Link)
+	if err != nil {
+		return fmt.Errorf("unable to parse link %s. Error: %s", row.Link, err.Error())
+	}
+
+	// only the missing parameters are appended, the existing ones stay as
+	// they are written
+	query := link.Query()
+	keys := make([]string, 0, len(c.UTM))
+	for key := range c.UTM {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	rawQuery := link.RawQuery
+	for _, key := range keys {
+		param := "utm_" + key
+		if query.Get(param) != "" {
+			continue
+		}
+		if rawQuery != "" {
+			rawQuery += "&"
+		}
+		rawQuery += url.QueryEscape(param) + "=" + url.QueryEscape(c.UTM[key])
+	}
+	link.RawQuery = rawQuery
+	row.Link = link.String()
+
+	return nil
+}
+
+// Progress counts the posted, pending and failed rows of every campaign.
+func Progress(rows []NextPinData, campaigns []Campaign) []CampaignProgress {
+	byName := map[string]*CampaignProgress{}
+	progress := make([]CampaignProgress, len(campaigns))
+	for i, c := range campaigns {
+		progress[i].Campaign = c
+		byName[c.Name] = &progress[i]
+	}
+
+	for _, row := range rows {
+		p, ok := byName[row.Campaign]
+		if !ok {
+			continue
+		}
+		switch RowStatus(row) {
+		case StatusPosted:
+			p.Posted++
+		case StatusFailed:
+			p.Failed++
+		default:
+			p.Pending++
+		}
+	}
+
+	sort.SliceStable(progress, func(i, j int) bool {
+		return progress[i].Campaign.Start.Before(progress[j].Campaign.Start)
+	})
+
+	return progress
+}
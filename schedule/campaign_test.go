@@ -0,0 +1,93 @@
+package schedule
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeSchedule(t *testing.T, content string) string {
+	filePath := filepath.Join(t.TempDir(), "schedule.csv")
+	err := os.WriteFile(filePath, []byte(content), 0o644)
+	assert.NoError(t, err)
+	return filePath
+}
+
+func TestNextRespectsCampaigns(t *testing.T) {
+	filePath := writeSchedule(t, "created;timestamp;board;title;description;filePath;link;campaign;status\n"+
+		"false;Thu, 01 Jan 2001 13:37:00 UTC;board;Paused;;a.png;https://example.com;paused;\n"+
+		"false;Thu, 01 Jan 2001 13:37:00 UTC;board;Failed;;a.png;https://example.com;active;failed\n"+
+		"false;Thu, 01 Jan 2001 13:37:00 UTC;board;Ended;;a.png;https://example.com;ended;\n"+
+		"false;Thu, 01 Jan 2001 13:37:00 UTC;;Active;;a.png;https://example.com/?utm_source=mail;active;\n")
+
+	campaigns := []Campaign{
+		{Name: "paused", Paused: true},
+		{Name: "ended", End: time.Date(2001, 1, 31, 0, 0, 0, 0, time.UTC)},
+		{
+			Name:         "active",
+			Start:        time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC),
+			DefaultBoard: "campaign board",
+			UTM:          map[string]string{"source": "pinterest", "campaign": "active"},
+		},
+	}
+
+	next, err := NewScheduleReader(filePath).WithCampaigns(campaigns).Next()
+	assert.NoError(t, err)
+	assert.NotNil(t, next)
+	assert.Equal(t, "Active", next.Title)
+	assert.Equal(t, "campaign board", next.BoardName)
+	assert.Equal(t, "https://example.com/?utm_source=mail&utm_campaign=active", next.Link)
+
+	// rows of unknown campaigns are skipped
+	next, err = NewScheduleReader(filePath).Next()
+	assert.NoError(t, err)
+	assert.Nil(t, next)
+
+	rows, err := NewScheduleReader(filePath).All()
+	assert.NoError(t, err)
+
+	progress := Progress(rows, campaigns)
+	assert.Equal(t, 3, len(progress))
+	for _, p := range progress {
+		if p.Campaign.Name == "active" {
+			assert.Equal(t, 1, p.Pending)
+			assert.Equal(t, 1, p.Failed)
+		}
+	}
+}
+
+func TestCampaignState(t *testing.T) {
+	c := Campaign{
+		Start: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC),
+	}
+
+	assert.Equal(t, CampaignScheduled, c.State(time.Date(2024, 5, 31, 23, 0, 0, 0, time.UTC)))
+	assert.Equal(t, CampaignActive, c.State(time.Date(2024, 6, 30, 23, 0, 0, 0, time.UTC)))
+	assert.Equal(t, CampaignEnded, c.State(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)))
+
+	c.Paused = true
+	assert.Equal(t, CampaignPaused, c.State(time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestNextSkipsUnknownCampaigns(t *testing.T) {
+	filePath := writeSchedule(t, "created;timestamp;board;title;description;filePath;link;campaign\n"+
+		"false;Thu, 01 Jan 2001 13:37:00 UTC;board;Unknown;;a.png;https://example.com;missing\n"+
+		"false;Thu, 01 Jan 2001 13:37:00 UTC;board;Plain;;a.png;https://example.com;\n")
+
+	next, err := NewScheduleReader(filePath).Next()
+	assert.NoError(t, err)
+	assert.NotNil(t, next)
+	assert.Equal(t, "Plain", next.Title)
+}
+
+func TestCampaignKeepsExistingQuery(t *testing.T) {
+	c := Campaign{UTM: map[string]string{"source": "pinterest", "medium": "social"}}
+	row := &NextPinData{Link: "https://example.com/?z=1&a=hello%20world&utm_source=mail"}
+
+	assert.NoError(t, c.apply(row))
+	assert.Equal(t, "https://example.com/?z=1&a=hello%20world&utm_source=mail&utm_medium=social", row.Link)
+}
@@ -0,0 +1,92 @@
+package schedule
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// CarouselItem is one image of a carousel pin. Empty fields are left to
+// Pinterest.
+type CarouselItem struct {
+	ImagePath   string
+	Title       string
+	Description string
+	Link        string
+}
+
+// parseCarouselItems returns the images of a row that names several images
+// separated by | or a directory. Directories contain the images in file name
+// order. Rows with a single image have no items.
+func parseCarouselItems(imagePath string, titles string, descriptions string, links string) ([]CarouselItem, error) {
+	var paths []string
+	if strings.Contains(imagePath, variantSeparator) {
+		paths = splitVariants(imagePath)
+	} else if info, err := os.Stat(imagePath); err == nil && info.IsDir() {
+		paths, err = listImages(imagePath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	itemTitles := splitVariants(titles)
+	itemDescriptions := splitVariants(descriptions)
+	itemLinks := splitVariants(links)
+
+	items := make([]CarouselItem, len(paths))
+	for i, path := range paths {
+		items[i] = CarouselItem{
+			ImagePath:   path,
+			Title:       itemAt(itemTitles, i),
+			Description: itemAt(itemDescriptions, i),
+			Link:        itemAt(itemLinks, i),
+		}
+	}
+
+	return items, nil
+}
+
+// formatCarouselItems returns the item title, description and link columns of
+// the items, the inverse of parseCarouselItems.
+func formatCarouselItems(items []CarouselItem) (string, string, string) {
+	titles := make([]string, len(items))
+	descriptions := make([]string, len(items))
+	links := make([]string, len(items))
+	for i, item := range items {
+		titles[i] = item.Title
+		descriptions[i] = item.Description
+		links[i] = item.Link
+	}
+
+	return joinVariants(titles), joinVariants(descriptions), joinVariants(links)
+}
+
+func listImages(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		paths = append(paths, filepath.Join(dir, entry.Name()))
+	}
+	sort.Strings(paths)
+
+	return paths, nil
+}
+
+func itemAt(values []string, i int) string {
+	if i < len(values) {
+		return values[i]
+	}
+	return ""
+}
@@ -0,0 +1,34 @@
+package schedule
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCarouselItems(t *testing.T) {
+	items, err := parseCarouselItems("a.png | b.png|c.png", "A||C", "", "https://example.com/a")
+	assert.NoError(t, err)
+	assert.Equal(t, []CarouselItem{
+		{ImagePath: "a.png", Title: "A", Link: "https://example.com/a"},
+		{ImagePath: "b.png"},
+		{ImagePath: "c.png", Title: "C"},
+	}, items)
+
+	dir := t.TempDir()
+	for _, name := range []string{"2.png", "1.png", ".DS_Store"} {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o644))
+	}
+	items, err = parseCarouselItems(dir, "", "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, []CarouselItem{
+		{ImagePath: filepath.Join(dir, "1.png")},
+		{ImagePath: filepath.Join(dir, "2.png")},
+	}, items)
+
+	items, err = parseCarouselItems("a.png", "", "", "")
+	assert.NoError(t, err)
+	assert.Nil(t, items)
+}
@@ -0,0 +1,108 @@
+package schedule
+
+import (
+	"fmt"
+)
+
+const (
+	columnCreated     = "created"
+	columnTimestamp   = "timestamp"
+	columnBoard       = "board"
+	columnTitle       = "title"
+	columnDescription = "description"
+	columnFilePath    = "filePath"
+	columnLink        = "link"
+
+	// optional columns
+	columnCampaign            = "campaign"
+	columnStatus              = "status"
+	columnSection             = "section"
+	columnTitleVariants       = "title_variants"
+	columnDescriptionVariants = "description_variants"
+	columnVariant             = "variant"
+	columnPinId               = "pin_id"
+	columnCover               = "cover"
+	columnItemTitles          = "item_titles"
+	columnItemDescriptions    = "item_descriptions"
+	columnItemLinks           = "item_links"
+)
+
+var requiredColumns = []string{
+	columnCreated,
+	columnTimestamp,
+	columnBoard,
+	columnTitle,
+	columnDescription,
+	columnFilePath,
+	columnLink,
+}
+
+// optionalColumns are added to the schedule when a row needs them.
+var optionalColumns = []string{
+	columnCampaign,
+	columnStatus,
+	columnSection,
+	columnTitleVariants,
+	columnDescriptionVariants,
+	columnVariant,
+	columnPinId,
+	columnCover,
+	columnItemTitles,
+	columnItemDescriptions,
+	columnItemLinks,
+}
+
+// columns maps the header names of the schedule file to their position so
+// rows can be read and written independently of the column order.
+type columns map[string]int
+
+func newColumns(allLines [][]string) (columns, error) {
+	if len(allLines) == 0 {
+		return nil, fmt.Errorf("csv file has no header")
+	}
+
+	cols := columns{}
+	for i, name := range allLines[0] {
+		cols[name] = i
+	}
+
+	for _, name := range requiredColumns {
+		if _, ok := cols[name]; !ok {
+			return nil, fmt.Errorf("csv file is missing the %s column", name)
+		}
+	}
+
+	return cols, nil
+}
+
+func (c columns) get(line []string, name string) string {
+	i, ok := c[name]
+	if !ok || i >= len(line) {
+		return ""
+	}
+	return line[i]
+}
+
+func (c columns) set(line []string, name string, value string) {
+	i, ok := c[name]
+	if !ok || i >= len(line) {
+		return
+	}
+	line[i] = value
+}
+
+// ensure appends the column to the header and pads every line if the schedule
+// doesn't have it yet.
+func (c columns) ensure(allLines [][]string, name string) {
+	if _, ok := c[name]; ok {
+		return
+	}
+
+	c[name] = len(allLines[0])
+	allLines[0] = append(allLines[0], name)
+	for i := 1; i < len(allLines); i++ {
+		for len(allLines[i]) < len(allLines[0]) {
+			allLines[i] = append(allLines[i], "")
+		}
+	}
+}
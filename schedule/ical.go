@@ -0,0 +1,332 @@
+package schedule
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	icalTimeFormat    = "20060102T150405Z"
+	icalLocalFormat   = "20060102T150405"
+	icalDateFormat    = "20060102"
+	icalLineLength    = 75
+	icalEventDuration = 15 * time.Minute
+
+	icalPropBoard    = "X-PIN-CREATOR-BOARD"
+	icalPropImage    = "X-PIN-CREATOR-IMAGE"
+	icalPropStatus   = "X-PIN-CREATOR-STATUS"
+	icalPropCampaign = "X-PIN-CREATOR-CAMPAIGN"
+)
+
+// icalColumns are the schedule columns kept in properties named after the
+// column, so export and import don't lose them.
+var icalColumns = []string{
+	columnSection,
+	columnCover,
+	columnTitleVariants,
+	columnDescriptionVariants,
+	columnVariant,
+	columnPinId,
+	columnItemTitles,
+	columnItemDescriptions,
+	columnItemLinks,
+}
+
+// ExportICS writes every row as a VEVENT so the schedule can be viewed in any
+// calendar application. Rows without a board show the default board of their
+// campaign.
+func ExportICS(w io.Writer, rows []NextPinData, campaigns []Campaign) error {
+	campaignsByName := make(map[string]Campaign, len(campaigns))
+	for _, c := range campaigns {
+		campaignsByName[c.Name] = c
+	}
+
+	bw := bufio.NewWriter(w)
+	now := time.Now().UTC().Format(icalTimeFormat)
+
+	writeICSLine(bw, "BEGIN:VCALENDAR")
+	writeICSLine(bw, "VERSION:2.0")
+	writeICSLine(bw, "PRODID:-//pin-creator//schedule//EN")
+	writeICSLine(bw, "CALSCALE:GREGORIAN")
+
+	for _, row := range rows {
+		status := RowStatus(row)
+		eventStatus := "TENTATIVE"
+		if row.Created {
+			eventStatus = "CONFIRMED"
+		}
+
+		board := row.BoardName
+		if board == "" && row.Campaign != "" {
+			campaign, ok := campaignsByName[row.Campaign]
+			if !ok {
+				return fmt.Errorf("row %d references unknown campaign %s", row.Index, row.Campaign)
+			}
+			board = campaign.DefaultBoard
+		}
+
+		start := row.Timestamp.UTC()
+		description := row.Description
+		if description != "" {
+			description += "\n\n"
+		}
+		description += icsFooter(board, status)
+
+		writeICSLine(bw, "BEGIN:VEVENT")
+		writeICSLine(bw, fmt.Sprintf("UID:row-%d-%s@pin-creator", row.Index, start.Format(icalTimeFormat)))
+		writeICSLine(bw, "DTSTAMP:"+now)
+		writeICSLine(bw, "DTSTART:"+start.Format(icalTimeFormat))
+		writeICSLine(bw, "DTEND:"+start.Add(icalEventDuration).Format(icalTimeFormat))
+		writeICSLine(bw, "SUMMARY:"+escapeICSText(row.Title))
+		writeICSLine(bw, "DESCRIPTION:"+escapeICSText(description))
+		if row.Link != "" {
+			writeICSLine(bw, "URL:"+row.Link)
+		}
+		writeICSLine(bw, "CATEGORIES:"+escapeICSText(board))
+		writeICSLine(bw, "STATUS:"+eventStatus)
+		writeICSLine(bw, icalPropBoard+":"+escapeICSText(row.BoardName))
+		writeICSLine(bw, icalPropImage+":"+escapeICSText(row.ImagePath))
+		writeICSLine(bw, icalPropStatus+":"+status)
+		if row.Campaign != "" {
+			writeICSLine(bw, icalPropCampaign+":"+escapeICSText(row.Campaign))
+		}
+		values := rowValues(row)
+		for _, name := range icalColumns {
+			if values[name] != "" {
+				writeICSLine(bw, icalColumnProperty(name)+":"+escapeICSText(values[name]))
+			}
+		}
+		writeICSLine(bw, "END:VEVENT")
+	}
+
+	writeICSLine(bw, "END:VCALENDAR")
+
+	return bw.Flush()
+}
+
+// ImportICS creates schedule rows from the VEVENTs of an iCalendar file. The
+// event summary becomes the title, the description the description and the URL
+// the link. Events exported by ExportICS keep their board, campaign, image and
+// status; for other events defaultBoard and defaultImage are used. Events that
+// end up without an image are rejected.
+func ImportICS(r io.Reader, defaultBoard string, defaultImage string) ([]NextPinData, error) {
+	lines, err := unfoldICSLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []NextPinData
+	var event map[string]icsProperty
+	for _, line := range lines {
+		prop, err := parseICSProperty(line)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case prop.name == "BEGIN" && prop.value == "VEVENT":
+			event = map[string]icsProperty{}
+		case prop.name == "END" && prop.value == "VEVENT":
+			if event == nil {
+				return nil, fmt.Errorf("unexpected END:VEVENT in ics file")
+			}
+			row, err := rowFromEvent(event, defaultBoard, defaultImage)
+			if err != nil {
+				return nil, err
+			}
+			rows = append(rows, row)
+			event = nil
+		case event != nil:
+			if _, ok := event[prop.name]; !ok {
+				event[prop.name] = prop
+			}
+		}
+	}
+
+	return rows, nil
+}
+
+func rowFromEvent(event map[string]icsProperty, defaultBoard string, defaultImage string) (NextPinData, error) {
+	row := NextPinData{}
+
+	start, ok := event["DTSTART"]
+	if !ok {
+		return row, fmt.Errorf("event %q has no DTSTART", event["SUMMARY"].value)
+	}
+	timestamp, err := parseICSTime(start)
+	if err != nil {
+		return row, err
+	}
+
+	row.Timestamp = timestamp
+	row.Title = unescapeICSText(event["SUMMARY"].value)
+	row.Description = unescapeICSText(event["DESCRIPTION"].value)
+	row.Link = event["URL"].value
+
+	// rows of a campaign may leave the board to the campaign's default board
+	campaign := unescapeICSText(event[icalPropCampaign].value)
+	categories := unescapeICSText(event["CATEGORIES"].value)
+	row.BoardName = unescapeICSText(event[icalPropBoard].value)
+	if row.BoardName == "" && campaign == "" {
+		row.BoardName = strings.SplitN(categories, ",", 2)[0]
+		if row.BoardName == "" {
+			row.BoardName = defaultBoard
+		}
+		if row.BoardName == "" {
+			return row, fmt.Errorf("event %q has no board and no default board is set", row.Title)
+		}
+	}
+
+	row.ImagePath = unescapeICSText(event[icalPropImage].value)
+	if row.ImagePath == "" {
+		row.ImagePath = defaultImage
+	}
+	if row.ImagePath == "" {
+		return row, fmt.Errorf("event %q has no image and no default image is set", row.Title)
+	}
+
+	status := event[icalPropStatus].value
+	row.Created = status == StatusPosted
+
+	// strip the footer added by ExportICS
+	row.Description = strings.TrimSuffix(row.Description, icsFooter(categories, status))
+	row.Description = strings.TrimRight(row.Description, "\n")
+
+	err = parseOptionalColumns(&row, func(name string) string {
+		switch name {
+		case columnCampaign:
+			return campaign
+		case columnStatus:
+			return status
+		}
+		return unescapeICSText(event[icalColumnProperty(name)].value)
+	})
+	if err != nil {
+		return row, fmt.Errorf("event %q: %w", row.Title, err)
+	}
+
+	return row, nil
+}
+
+// icalColumnProperty returns the name of the property that keeps the schedule
+// column.
+func icalColumnProperty(column string) string {
+	return "X-PIN-CREATOR-" + strings.ToUpper(strings.ReplaceAll(column, "_", "-"))
+}
+
+func icsFooter(board string, status string) string {
+	return fmt.Sprintf("Board: %s\nStatus: %s", board, status)
+}
+
+type icsProperty struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+func parseICSProperty(line string) (icsProperty, error) {
+	prop := icsProperty{params: map[string]string{}}
+
+	colon := strings.Index(line, ":")
+	if colon < 0 {
+		return prop, fmt.Errorf("invalid ics line %q", line)
+	}
+
+	nameAndParams := strings.Split(line[:colon], ";")
+	prop.name = strings.ToUpper(nameAndParams[0])
+	prop.value = line[colon+1:]
+	for _, param := range nameAndParams[1:] {
+		kv := strings.SplitN(param, "=", 2)
+		if len(kv) == 2 {
+			prop.params[strings.ToUpper(kv[0])] = strings.Trim(kv[1], `"`)
+		}
+	}
+
+	return prop, nil
+}
+
+func parseICSTime(prop icsProperty) (time.Time, error) {
+	value := prop.value
+
+	if prop.params["VALUE"] == "DATE" || len(value) == len(icalDateFormat) {
+		return time.Parse(icalDateFormat, value)
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		return time.Parse(icalTimeFormat, value)
+	}
+
+	location := time.UTC
+	if tzid, ok := prop.params["TZID"]; ok {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			location = l
+		}
+	}
+
+	t, err := time.ParseInLocation(icalLocalFormat, value, location)
+	if err != nil {
+		return t, fmt.Errorf("unable to parse ics time %s. Error: %s", value, err.Error())
+	}
+	return t.UTC(), nil
+}
+
+func unfoldICSLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		if (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read ics file. Error: %s", err.Error())
+	}
+	return lines, nil
+}
+
+func writeICSLine(w *bufio.Writer, line string) {
+	// fold lines longer than 75 octets without splitting UTF-8 sequences
+	for len(line) > icalLineLength {
+		cut := icalLineLength
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		w.WriteString(line[:cut] + "\r\n")
+		line = " " + line[cut:]
+	}
+	w.WriteString(line + "\r\n")
+}
+
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+
+func escapeICSText(s string) string {
+	return icsEscaper.Replace(s)
+}
+
+func unescapeICSText(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+			switch s[i] {
+			case 'n', 'N':
+				b.WriteByte('\n')
+			default:
+				b.WriteByte(s[i])
+			}
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,184 @@
+package schedule
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestICSRoundTrip(t *testing.T) {
+	rows := []NextPinData{
+		{
+			Index:       1,
+			Created:     true,
+			Timestamp:   time.Date(2001, 1, 1, 13, 37, 0, 0, time.UTC),
+			BoardName:   "testboard",
+			Title:       "Second Video",
+			Description: "WATCH IT NOW; really, it is good\nsecond line",
+			ImagePath:   "secondVideoThumbnail.png",
+			Link:        "https://www.youtube.com/watch?v=e2fFMAPzZs4",
+		},
+		{
+			Index:     2,
+			Timestamp: time.Date(2111, 1, 1, 13, 37, 0, 0, time.UTC),
+			BoardName: "other board",
+			Title:     strings.Repeat("a very long title ", 10),
+			ImagePath: "thumbnail.png",
+		},
+	}
+
+	var buf bytes.Buffer
+	err := ExportICS(&buf, rows, nil)
+	assert.NoError(t, err)
+
+	for _, line := range strings.Split(buf.String(), "\r\n") {
+		assert.True(t, len(line) <= icalLineLength)
+	}
+
+	imported, err := ImportICS(&buf, "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(imported))
+
+	for i, row := range parsed(rows) {
+		row.Index = 0
+		assert.Equal(t, row, imported[i])
+	}
+}
+
+// parsed adds the single variant every row read from a schedule has.
+func parsed(rows []NextPinData) []NextPinData {
+	for i := range rows {
+		if rows[i].Variants == nil {
+			rows[i].Variants = []Variant{{Title: rows[i].Title, Description: rows[i].Description}}
+		}
+	}
+	return rows
+}
+
+func TestImportICSDefaults(t *testing.T) {
+	ics := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART;TZID=Europe/Berlin:20240630T120000\r\n" +
+		"SUMMARY:Summer\\, sale\r\n" +
+		"DESCRIPTION:Everything must go\r\n" +
+		"URL:https://example.com\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	rows, err := ImportICS(strings.NewReader(ics), "deals", "sale.png")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(rows))
+	assert.Equal(t, "Summer, sale", rows[0].Title)
+	assert.Equal(t, "Everything must go", rows[0].Description)
+	assert.Equal(t, "https://example.com", rows[0].Link)
+	assert.Equal(t, "deals", rows[0].BoardName)
+	assert.Equal(t, "sale.png", rows[0].ImagePath)
+	assert.False(t, rows[0].Created)
+	assert.True(t, time.Date(2024, 6, 30, 10, 0, 0, 0, time.UTC).Equal(rows[0].Timestamp))
+
+	_, err = ImportICS(strings.NewReader(ics), "", "")
+	assert.Error(t, err)
+}
+
+func TestICSRoundTripKeepsFailedStatus(t *testing.T) {
+	rows := []NextPinData{
+		{
+			Timestamp: time.Date(2001, 1, 1, 13, 37, 0, 0, time.UTC),
+			BoardName: "board",
+			Title:     "Failed",
+			ImagePath: "a.png",
+			Failed:    true,
+		},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, ExportICS(&buf, rows, nil))
+
+	imported, err := ImportICS(&buf, "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, parsed(rows), imported)
+
+	filePath := writeSchedule(t, "created;timestamp;board;title;description;filePath;link\n")
+	reader := NewScheduleReader(filePath)
+	assert.NoError(t, reader.Append(imported))
+
+	all, err := reader.All()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(all))
+	assert.Equal(t, StatusFailed, RowStatus(all[0]))
+}
+
+func TestICSRoundTripWithCampaign(t *testing.T) {
+	rows := []NextPinData{
+		{
+			Timestamp: time.Date(2001, 1, 1, 13, 37, 0, 0, time.UTC),
+			Title:     "Summer",
+			ImagePath: "a.png",
+			Campaign:  "summer",
+		},
+	}
+	campaigns := []Campaign{{Name: "summer", DefaultBoard: "deals"}}
+
+	var buf bytes.Buffer
+	assert.NoError(t, ExportICS(&buf, rows, campaigns))
+	assert.Contains(t, buf.String(), "CATEGORIES:deals\r\n")
+
+	imported, err := ImportICS(&buf, "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, parsed(rows), imported)
+
+	buf.Reset()
+	assert.Error(t, ExportICS(&buf, rows, nil))
+}
+
+func TestImportICSWithoutImage(t *testing.T) {
+	rows := []NextPinData{
+		{
+			Timestamp: time.Date(2001, 1, 1, 13, 37, 0, 0, time.UTC),
+			BoardName: "board",
+			Title:     "No image",
+		},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, ExportICS(&buf, rows, nil))
+	ics := buf.String()
+
+	_, err := ImportICS(strings.NewReader(ics), "", "")
+	assert.Error(t, err)
+
+	imported, err := ImportICS(strings.NewReader(ics), "", "default.png")
+	assert.NoError(t, err)
+	assert.Equal(t, "default.png", imported[0].ImagePath)
+}
+
+func TestICSRoundTripKeepsAllColumns(t *testing.T) {
+	filePath := writeSchedule(t, "created;timestamp;board;title;description;filePath;link;section;cover;title_variants;description_variants;variant;pin_id;item_titles;item_descriptions;item_links\n"+
+		"true;Mon, 01 Jan 2001 13:37:00 UTC;board;A;a;a.png;https://example.com;dinner;cover.png;B|C;b|;2;1234;;;\n"+
+		"false;Mon, 01 Jan 2001 13:37:00 UTC;board;Carousel;;1.png|2.png;;;;;;;;One|Two;|second;https://example.com/1|\n")
+
+	rows, err := NewScheduleReader(filePath).All()
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	assert.NoError(t, ExportICS(&buf, rows, nil))
+	imported, err := ImportICS(&buf, "", "")
+	assert.NoError(t, err)
+
+	appended := writeSchedule(t, "created;timestamp;board;title;description;filePath;link\n")
+	reader := NewScheduleReader(appended)
+	assert.NoError(t, reader.Append(imported))
+
+	roundTripped, err := reader.All()
+	assert.NoError(t, err)
+	assert.Equal(t, rows, roundTripped)
+
+	content, err := os.ReadFile(appended)
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	assert.Equal(t, "created;timestamp;board;title;description;filePath;link;status;section;title_variants;description_variants;variant;pin_id;cover;item_titles;item_descriptions;item_links", lines[0])
+}
@@ -6,93 +6,239 @@ import (
 	"os"
 	"strconv"
 	"time"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	StatusPending = "pending"
+	StatusPosted  = "posted"
+	StatusFailed  = "failed"
 )
 
 type NextPinData struct {
 	Created     bool
 	Timestamp   time.Time
 	BoardName   string
+	SectionName string
 	Title       string
 	Description string
 	ImagePath   string
+	Cover       string
+	Items       []CarouselItem
 	Link        string
+	Campaign    string
+	Failed      bool
+	Variants    []Variant
+	Variant     int
+	PinId       string
 	Index       int
 }
 
+// RowStatus returns the human readable posting state of a row.
+func RowStatus(row NextPinData) string {
+	if row.Created {
+		return StatusPosted
+	}
+	if row.Failed {
+		return StatusFailed
+	}
+	return StatusPending
+}
+
 type ScheduleReaderInterface interface {
 	Next() (*NextPinData, error)
-	SetCreated(index int) error
+	All() ([]NextPinData, error)
+	Append(rows []NextPinData) error
+	SetCreated(index int, pinId string, variant int) error
+	SetFailed(index int) error
 }
 
 type ScheduleReader struct {
-	filePath string
+	filePath        string
+	campaigns       map[string]Campaign
+	variantStrategy string
+	variantSeed     int64
+	log             logr.Logger
 }
 
 func NewScheduleReader(filePath string) *ScheduleReader {
 	return &ScheduleReader{
 		filePath: filePath,
+		log:      logr.Discard(),
 	}
 }
 
+// WithLogger sets the logger Next reports skipped rows to.
+func (r *ScheduleReader) WithLogger(log logr.Logger) *ScheduleReader {
+	r.log = log
+	return r
+}
+
+// WithCampaigns makes Next respect the windows, pause state and defaults of the
+// given campaigns.
+func (r *ScheduleReader) WithCampaigns(campaigns []Campaign) *ScheduleReader {
+	r.campaigns = make(map[string]Campaign, len(campaigns))
+	for _, c := range campaigns {
+		r.campaigns[c.Name] = c
+	}
+	return r
+}
+
+// WithVariantStrategy sets how Next chooses between the title and description
+// variants of a row. The seed is only used by the random strategy.
+func (r *ScheduleReader) WithVariantStrategy(strategy string, seed int64) *ScheduleReader {
+	r.variantStrategy = strategy
+	r.variantSeed = seed
+	return r
+}
+
 func (r *ScheduleReader) Next() (*NextPinData, error) {
 	now := time.Now()
 
-	allLines, err := readFile(r.filePath)
+	selector, err := newVariantSelector(r.variantStrategy, r.variantSeed)
 	if err != nil {
 		return nil, err
 	}
 
-	for i := 0; i < len(allLines); i++ {
+	rows, err := r.All()
+	if err != nil {
+		return nil, err
+	}
 
-		// skip header
-		if i == 0 {
-			continue
+	postedWithVariants := 0
+	for _, row := range rows {
+		if row.Created && len(row.Variants) > 1 {
+			postedWithVariants++
 		}
+	}
 
-		line := allLines[i]
+	for i := range rows {
+		nextPinData := &rows[i]
 
-		created, err := strconv.ParseBool(line[0])
-		if err != nil {
-			return nil, fmt.Errorf("unable to parse created value %s in csv file. Error: %s", line[0], err.Error())
+		if nextPinData.Created || nextPinData.Failed {
+			continue
 		}
 
-		if created {
+		if nextPinData.Timestamp.After(now) {
 			continue
 		}
 
-		timestamp, err := time.Parse(time.RFC1123, line[1])
-		if err != nil {
-			return nil, fmt.Errorf("unable to parse timestamp %s in csv file. Error: %s", line[1], err.Error())
+		if nextPinData.Campaign != "" {
+			campaign, ok := r.campaigns[nextPinData.Campaign]
+			if !ok {
+				r.log.Info(fmt.Sprintf("Skipping row %d, it references unknown campaign %s", nextPinData.Index, nextPinData.Campaign))
+				continue
+			}
+
+			if campaign.State(now) != CampaignActive {
+				continue
+			}
+
+			err = campaign.apply(nextPinData)
+			if err != nil {
+				return nil, err
+			}
 		}
 
-		if timestamp.After(now) {
-			continue
+		if nextPinData.BoardName == "" {
+			return nil, fmt.Errorf("row %d has no board", nextPinData.Index)
 		}
 
-		nextPinData := &NextPinData{}
-		nextPinData.Index = i
-		nextPinData.Created = created
-		nextPinData.Timestamp = timestamp
-		nextPinData.BoardName = line[2]
-		nextPinData.Title = line[3]
-		nextPinData.Description = line[4]
-		nextPinData.ImagePath = line[5]
-		nextPinData.Link = line[6]
+		nextPinData.Variant = selector.choose(nextPinData, postedWithVariants)
 
 		return nextPinData, nil
-
 	}
 
 	return nil, nil
 }
 
-func (r *ScheduleReader) SetCreated(index int) error {
+// All returns every row of the schedule regardless of its created state.
+func (r *ScheduleReader) All() ([]NextPinData, error) {
+	allLines, err := readFile(r.filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	cols, err := newColumns(allLines)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]NextPinData, 0, len(allLines))
+	for i := 1; i < len(allLines); i++ {
+		row, err := parseLine(cols, i, allLines[i])
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, *row)
+	}
+
+	return rows, nil
+}
+
+// Append adds rows to the end of the schedule. The Index of the given rows is
+// ignored.
+func (r *ScheduleReader) Append(rows []NextPinData) error {
+	allLines, err := readFile(r.filePath)
+	if err != nil {
+		return err
+	}
+
+	cols, err := newColumns(allLines)
+	if err != nil {
+		return err
+	}
+
+	values := make([]map[string]string, len(rows))
+	for i, row := range rows {
+		values[i] = rowValues(row)
+		for _, name := range optionalColumns {
+			if values[i][name] != "" {
+				cols.ensure(allLines, name)
+			}
+		}
+	}
+
+	for i := range rows {
+		line := make([]string, len(allLines[0]))
+		for name, value := range values[i] {
+			cols.set(line, name, value)
+		}
+		allLines = append(allLines, line)
+	}
+
+	return writeFile(r.filePath, allLines)
+}
+
+// SetCreated marks a row as posted and records the id of the created pin. The
+// chosen variant is only recorded for rows with variants.
+func (r *ScheduleReader) SetCreated(index int, pinId string, variant int) error {
 	allFiles, err := readFile(r.filePath)
 	if err != nil {
 		return err
 	}
 
-	allFiles[index][0] = "true"
+	cols, err := newColumns(allFiles)
+	if err != nil {
+		return err
+	}
+
+	hasVariants := cols.get(allFiles[index], columnTitleVariants) != "" || cols.get(allFiles[index], columnDescriptionVariants) != ""
+	if pinId != "" {
+		cols.ensure(allFiles, columnPinId)
+	}
+	if hasVariants {
+		cols.ensure(allFiles, columnVariant)
+	}
+
+	line := allFiles[index]
+	cols.set(line, columnCreated, "true")
+	cols.set(line, columnStatus, StatusPosted)
+	cols.set(line, columnPinId, pinId)
+	if hasVariants {
+		cols.set(line, columnVariant, strconv.Itoa(variant))
+	}
 
 	err = writeFile(r.filePath, allFiles)
 	if err != nil {
@@ -101,6 +247,124 @@ func (r *ScheduleReader) SetCreated(index int) error {
 	return nil
 }
 
+// SetFailed marks a row as failed so Next skips it.
+func (r *ScheduleReader) SetFailed(index int) error {
+	allLines, err := readFile(r.filePath)
+	if err != nil {
+		return err
+	}
+
+	cols, err := newColumns(allLines)
+	if err != nil {
+		return err
+	}
+
+	cols.ensure(allLines, columnStatus)
+	cols.set(allLines[index], columnStatus, StatusFailed)
+
+	return writeFile(r.filePath, allLines)
+}
+
+func parseLine(cols columns, index int, line []string) (*NextPinData, error) {
+	created, err := strconv.ParseBool(cols.get(line, columnCreated))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse created value %s in csv file. Error: %s", cols.get(line, columnCreated), err.Error())
+	}
+
+	timestamp, err := time.Parse(time.RFC1123, cols.get(line, columnTimestamp))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse timestamp %s in csv file. Error: %s", cols.get(line, columnTimestamp), err.Error())
+	}
+
+	nextPinData := &NextPinData{}
+	nextPinData.Index = index
+	nextPinData.Created = created
+	nextPinData.Timestamp = timestamp
+	nextPinData.BoardName = cols.get(line, columnBoard)
+	nextPinData.Title = cols.get(line, columnTitle)
+	nextPinData.Description = cols.get(line, columnDescription)
+	nextPinData.ImagePath = cols.get(line, columnFilePath)
+	nextPinData.Link = cols.get(line, columnLink)
+
+	err = parseOptionalColumns(nextPinData, func(name string) string {
+		return cols.get(line, name)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return nextPinData, nil
+}
+
+// parseOptionalColumns reads the columns a schedule may leave out. The title,
+// description and image of the row have to be set already.
+func parseOptionalColumns(nextPinData *NextPinData, get func(name string) string) error {
+	nextPinData.SectionName = get(columnSection)
+	nextPinData.Cover = get(columnCover)
+	nextPinData.Campaign = get(columnCampaign)
+	nextPinData.Failed = get(columnStatus) == StatusFailed
+	nextPinData.PinId = get(columnPinId)
+	nextPinData.Variants = parseVariants(
+		nextPinData.Title,
+		nextPinData.Description,
+		get(columnTitleVariants),
+		get(columnDescriptionVariants),
+	)
+
+	var err error
+	nextPinData.Items, err = parseCarouselItems(
+		nextPinData.ImagePath,
+		get(columnItemTitles),
+		get(columnItemDescriptions),
+		get(columnItemLinks),
+	)
+	if err != nil {
+		return fmt.Errorf("unable to read carousel images %s. Error: %s", nextPinData.ImagePath, err.Error())
+	}
+
+	if variant := get(columnVariant); variant != "" {
+		nextPinData.Variant, err = strconv.Atoi(variant)
+		if err != nil {
+			return fmt.Errorf("unable to parse variant %s in csv file. Error: %s", variant, err.Error())
+		}
+		if nextPinData.Variant < 0 {
+			return fmt.Errorf("unable to parse variant %s in csv file. Error: variant must not be negative", variant)
+		}
+	}
+
+	return nil
+}
+
+// rowValues returns the value of every column for the row, the inverse of
+// parseLine.
+func rowValues(row NextPinData) map[string]string {
+	values := map[string]string{
+		columnCreated:     strconv.FormatBool(row.Created),
+		columnTimestamp:   row.Timestamp.Format(time.RFC1123),
+		columnBoard:       row.BoardName,
+		columnTitle:       row.Title,
+		columnDescription: row.Description,
+		columnFilePath:    row.ImagePath,
+		columnLink:        row.Link,
+		columnSection:     row.SectionName,
+		columnCover:       row.Cover,
+		columnCampaign:    row.Campaign,
+		columnPinId:       row.PinId,
+	}
+	if row.Created || row.Failed {
+		values[columnStatus] = RowStatus(row)
+	}
+
+	values[columnTitleVariants], values[columnDescriptionVariants] = formatVariants(row.Variants)
+	if len(row.Variants) > 1 && row.Created {
+		values[columnVariant] = strconv.Itoa(row.Variant)
+	}
+
+	values[columnItemTitles], values[columnItemDescriptions], values[columnItemLinks] = formatCarouselItems(row.Items)
+
+	return values
+}
+
 func readFile(csvFile string) ([][]string, error) {
 	csvfile, err := os.Open(csvFile)
 	if err != nil {
@@ -0,0 +1,28 @@
+package schedule
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetFailed(t *testing.T) {
+	filePath := writeSchedule(t, "created;timestamp;board;title;description;filePath;link\n"+
+		"false;Thu, 01 Jan 2001 13:37:00 UTC;board;First;;a.png;https://example.com\n"+
+		"false;Thu, 01 Jan 2001 13:37:00 UTC;board;Second;;b.png;https://example.com\n")
+
+	reader := NewScheduleReader(filePath)
+	next, err := reader.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, "First", next.Title)
+
+	assert.NoError(t, reader.SetFailed(next.Index))
+
+	next, err = reader.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, "Second", next.Title)
+
+	rows, err := reader.All()
+	assert.NoError(t, err)
+	assert.Equal(t, StatusFailed, RowStatus(rows[0]))
+}
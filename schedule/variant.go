@@ -0,0 +1,123 @@
+package schedule
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+const (
+	VariantRoundRobin = "round-robin"
+	VariantRandom     = "random"
+
+	variantSeparator = "|"
+)
+
+// Variant is one title and description combination of a row. The first variant
+// of a row is always its title and description column.
+type Variant struct {
+	Title       string
+	Description string
+}
+
+type variantSelector struct {
+	strategy string
+	seed     int64
+}
+
+func newVariantSelector(strategy string, seed int64) (variantSelector, error) {
+	switch strategy {
+	case "":
+		strategy = VariantRoundRobin
+	case VariantRoundRobin, VariantRandom:
+	default:
+		return variantSelector{}, fmt.Errorf("unknown variant strategy %s", strategy)
+	}
+
+	return variantSelector{strategy: strategy, seed: seed}, nil
+}
+
+// choose returns the index of the variant to post. Round-robin rotates through
+// the variants with every posted row that has variants, random picks a variant
+// that is stable for a row and seed.
+func (s variantSelector) choose(row *NextPinData, postedWithVariants int) int {
+	if len(row.Variants) < 2 {
+		return 0
+	}
+
+	if s.strategy == VariantRandom {
+		r := rand.New(rand.NewSource(s.seed + int64(row.Index)))
+		return r.Intn(len(row.Variants))
+	}
+
+	return postedWithVariants % len(row.Variants)
+}
+
+func parseVariants(title string, description string, titleVariants string, descriptionVariants string) []Variant {
+	variants := []Variant{{Title: title, Description: description}}
+
+	titles := splitVariants(titleVariants)
+	descriptions := splitVariants(descriptionVariants)
+	for i := 0; i < len(titles) || i < len(descriptions); i++ {
+		variant := Variant{Title: title, Description: description}
+		if i < len(titles) && titles[i] != "" {
+			variant.Title = titles[i]
+		}
+		if i < len(descriptions) && descriptions[i] != "" {
+			variant.Description = descriptions[i]
+		}
+		variants = append(variants, variant)
+	}
+
+	return variants
+}
+
+// formatVariants returns the title and description variant columns of the
+// variants, the inverse of parseVariants. Fields equal to the first variant
+// are left empty.
+func formatVariants(variants []Variant) (string, string) {
+	if len(variants) < 2 {
+		return "", ""
+	}
+
+	titles := make([]string, len(variants)-1)
+	descriptions := make([]string, len(variants)-1)
+	for i, variant := range variants[1:] {
+		if variant.Title != variants[0].Title {
+			titles[i] = variant.Title
+		}
+		if variant.Description != variants[0].Description {
+			descriptions[i] = variant.Description
+		}
+	}
+
+	titleVariants, descriptionVariants := joinVariants(titles), joinVariants(descriptions)
+	if titleVariants == "" && descriptionVariants == "" {
+		// keep the number of variants that only repeat the first one
+		titleVariants = strings.Join(titles, variantSeparator)
+	}
+	return titleVariants, descriptionVariants
+}
+
+// joinVariants joins the values, or returns an empty string if all of them are
+// empty.
+func joinVariants(values []string) string {
+	for _, value := range values {
+		if value != "" {
+			return strings.Join(values, variantSeparator)
+		}
+	}
+	return ""
+}
+
+func splitVariants(value string) []string {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+
+	parts := strings.Split(value, variantSeparator)
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	return parts
+}
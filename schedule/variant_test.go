@@ -0,0 +1,51 @@
+package schedule
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVariantsRoundRobin(t *testing.T) {
+	filePath := writeSchedule(t, "created;timestamp;board;title;description;filePath;link;title_variants;description_variants\n"+
+		"true;Thu, 01 Jan 2001 13:37:00 UTC;board;A;a;a.png;;B|C;\n"+
+		"false;Thu, 01 Jan 2001 13:37:00 UTC;board;A;a;a.png;;B|C;b|\n")
+
+	reader := NewScheduleReader(filePath)
+	next, err := reader.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, []Variant{{"A", "a"}, {"B", "b"}, {"C", "a"}}, next.Variants)
+	assert.Equal(t, 1, next.Variant)
+
+	err = reader.SetCreated(next.Index, "1234", next.Variant)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filePath)
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	assert.Equal(t, "created;timestamp;board;title;description;filePath;link;title_variants;description_variants;pin_id;variant", lines[0])
+	assert.Equal(t, "true;Thu, 01 Jan 2001 13:37:00 UTC;board;A;a;a.png;;B|C;;;", lines[1])
+	assert.Equal(t, "true;Thu, 01 Jan 2001 13:37:00 UTC;board;A;a;a.png;;B|C;b|;1234;1", lines[2])
+}
+
+func TestVariantsRandomIsStable(t *testing.T) {
+	row := &NextPinData{Index: 3, Variants: []Variant{{}, {}, {}, {}}}
+
+	selector, err := newVariantSelector(VariantRandom, 42)
+	assert.NoError(t, err)
+	assert.Equal(t, selector.choose(row, 0), selector.choose(row, 5))
+
+	_, err = newVariantSelector("weighted", 0)
+	assert.Error(t, err)
+}
+
+func TestNegativeVariantIsRejected(t *testing.T) {
+	filePath := writeSchedule(t, "created;timestamp;board;title;description;filePath;link;title_variants;variant\n"+
+		"true;Thu, 01 Jan 2001 13:37:00 UTC;board;A;a;a.png;;B|C;-1\n")
+
+	_, err := NewScheduleReader(filePath).All()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to parse variant -1")
+}